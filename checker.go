@@ -3,13 +3,26 @@
 package quicktest
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"math/cmplx"
 	"reflect"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 // Checker is implemented by types used as part of Check/Assert invocations.
@@ -17,6 +30,11 @@ type Checker interface {
 	// Check performs the check and returns an error in the case it fails.
 	// The check is performed using the provided got argument and any
 	// additional args required.
+	// The error message is included in the failure output. Use BadCheckf
+	// to report a misuse of the checker, like arguments of the wrong type:
+	// such errors should be returned by both Check and Negate, so that they
+	// are reported even when the checker is negated. Use SilentFailuref to
+	// report a failure without including the message in the output.
 	Check(got interface{}, args []interface{}) error
 	// Negate negates the check performed by Check. In essence, it checks that
 	// the opposite is true. For instance, if Check ensures that two values are
@@ -27,11 +45,46 @@ type Checker interface {
 	NumArgs() int
 }
 
+// VariadicChecker is implemented by checkers accepting a variable number of
+// additional arguments. When Variadic returns true, the value returned by
+// NumArgs is the minimum number of arguments to be provided, and any further
+// arguments are passed to Check and Negate as well. For instance, a checker
+// with a NumArgs of 1 can be used as:
+//
+//     c.Assert(got, myVariadicChecker, "a")
+//     c.Assert(got, myVariadicChecker, "a", "b", "c")
+//
+// A Comment can still be provided as the last argument. Since a format
+// string cannot be told apart from the checker arguments, Checkf and Assertf
+// fail when called with a variadic checker and more than NumArgs arguments:
+// use Check or Assert with Commentf instead.
+type VariadicChecker interface {
+	Checker
+
+	// Variadic reports whether the checker accepts more than NumArgs
+	// arguments.
+	Variadic() bool
+}
+
+// isVariadic reports whether the given checker accepts a variable number of
+// arguments.
+func isVariadic(checker Checker) bool {
+	vc, ok := checker.(VariadicChecker)
+	return ok && vc.Variadic()
+}
+
 // Equals is a Checker checking equality of two comparable values.
 // For instance:
 //
 //     c.Assert(answer, qt.Equals, 42)
 //
+// If the provided value has an Equal method accepting the expected value and
+// returning a bool, like time.Time does, that method is used to check
+// equality instead of the == operator.
+// For instance:
+//
+//     c.Assert(t1, qt.Equals, t2)
+//
 // Note that the following will fail:
 //
 //     c.Assert((*sometype)(nil), qt.Equals, nil)
@@ -41,19 +94,66 @@ var Equals Checker = &equalsChecker{
 	numArgs: 1,
 }
 
+// EqualsDeep is a Checker checking equality of two values like Equals, but
+// falling back to reflect.DeepEqual when the values are not comparable with
+// the == operator, for instance when they are structs including slices.
+// For instance:
+//
+//     c.Assert(got, qt.EqualsDeep, Person{Name: "bad wolf", Tags: []string{"who"}})
+//
+// Use DeepEquals for a richer comparison and a detailed diff on failure.
+var EqualsDeep Checker = &equalsChecker{
+	numArgs: 1,
+	deep:    true,
+}
+
 type equalsChecker struct {
 	numArgs
+
+	// deep holds whether reflect.DeepEqual is used to compare values that
+	// are not comparable.
+	deep bool
 }
 
-// Check implements Checker.Check by checking that got == args[0].
+// Check implements Checker.Check by checking that got == args[0], or that
+// got.Equal(args[0]) returns true when got has a suitable Equal method.
 func (c *equalsChecker) Check(got interface{}, args []interface{}) (err error) {
 	defer func() {
-		// A panic is raised when the provided values are not comparable.
+		// A panic can be raised by the Equal method of the provided value.
 		if r := recover(); r != nil {
 			err = fmt.Errorf("%s", r)
 		}
 	}()
-	if want := args[0]; got != want {
+	want := args[0]
+	if equal, ok := callEqual(got, want); ok {
+		if !equal {
+			return &notEqualError{
+				msg:  "not equal",
+				got:  got,
+				want: want,
+			}
+		}
+		return nil
+	}
+	if c.deep {
+		equal, ok := compareValues(got, want)
+		if !ok {
+			equal = reflect.DeepEqual(got, want)
+		}
+		if !equal {
+			return &notEqualError{
+				msg:  "not equal",
+				got:  got,
+				want: want,
+			}
+		}
+		return nil
+	}
+	equal, ok := compareValues(got, want)
+	if !ok {
+		return BadCheckf("cannot compare uncomparable type %s with Equals; use DeepEquals or CmpEquals instead", uncomparableType(got, want))
+	}
+	if !equal {
 		return &notEqualError{
 			msg:  "not equal",
 			got:  got,
@@ -63,291 +163,4213 @@ func (c *equalsChecker) Check(got interface{}, args []interface{}) (err error) {
 	return nil
 }
 
-// Negate implements Checker.Negate by checking that got != args[0].
-func (c *equalsChecker) Negate(got interface{}, args []interface{}) error {
-	if c.Check(got, args) != nil {
-		return nil
-	}
-	return fmt.Errorf("both values equal %#v, but should not", got)
+// uncomparableType returns the name of the type of the given values that is
+// not comparable.
+func uncomparableType(got, want interface{}) string {
+	if t := reflect.TypeOf(got); t != nil && !t.Comparable() {
+		return t.String()
+	}
+	return reflect.TypeOf(want).String()
+}
+
+// compareValues reports whether got == want. The ok return value is false if
+// the values cannot be compared with the == operator.
+func compareValues(got, want interface{}) (equal, ok bool) {
+	defer func() {
+		// A panic is raised when the provided values are not comparable.
+		if recover() != nil {
+			equal, ok = false, false
+		}
+	}()
+	return got == want, true
+}
+
+// Negate implements Checker.Negate by checking that got != args[0].
+func (c *equalsChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "both values equal %#v", got)
+}
+
+// callEqual calls got.Equal(want) and reports its result. The ok return value
+// is false if got is a nil pointer or has no Equal method with signature
+// func(T) bool, where want is assignable to T.
+func callEqual(got, want interface{}) (equal, ok bool) {
+	if got == nil || want == nil {
+		return false, false
+	}
+	gotValue := reflect.ValueOf(got)
+	if gotValue.Kind() == reflect.Ptr && gotValue.IsNil() {
+		// Calling the method would most likely dereference the nil receiver.
+		return false, false
+	}
+	m := gotValue.MethodByName("Equal")
+	if !m.IsValid() {
+		return false, false
+	}
+	mtype := m.Type()
+	if mtype.NumIn() != 1 || mtype.NumOut() != 1 || mtype.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	wantValue := reflect.ValueOf(want)
+	if !wantValue.Type().AssignableTo(mtype.In(0)) {
+		return false, false
+	}
+	return m.Call([]reflect.Value{wantValue})[0].Bool(), true
+}
+
+// CmpEquals returns a Checker checking equality of two arbitrary values
+// according to the provided compare options. See DeepEquals as an example of
+// such a checker, commonly used when no compare options are required.
+// For instance:
+//
+//     c.Assert(list, qt.CmpEquals(cmpopts.SortSlices), []int{42, 47})
+//     c.Assert(got, qt.CmpEquals(), []int{42, 47}) // Same as qt.DeepEquals.
+//
+// Additional compare options can also be provided when calling Check or
+// Assert, right after the expected value, in which case they are used in
+// addition to the ones stored in the checker, only for that call.
+// For instance:
+//
+//     c.Assert(got, qt.DeepEquals, want, cmpopts.IgnoreFields(Person{}, "ID"))
+//
+func CmpEquals(opts ...cmp.Option) Checker {
+	return &cmpEqualsChecker{
+		numArgs: 1,
+		opts:    opts,
+	}
+}
+
+// CmpEqualsWithComparer returns a Checker checking equality of two arbitrary
+// values using go-cmp, where values of type T are compared using the given
+// function, which must be of type func(x, y T) bool. It is a shortcut for
+// CmpEquals(cmp.Comparer(f)), so that go-cmp does not need to be imported for
+// the common case of a single custom comparison. See cmp.Comparer for the
+// properties the comparison function must satisfy.
+// For instance:
+//
+//     bigIntEquals := qt.CmpEqualsWithComparer(func(x, y *big.Int) bool {
+//         return x.Cmp(y) == 0
+//     })
+//     c.Assert(got, bigIntEquals, []*big.Int{big.NewInt(42)})
+//
+// If f is not a valid comparison function, the checker always fails with a
+// bad check error describing the problem.
+func CmpEqualsWithComparer(f interface{}) Checker {
+	if err := checkComparer(f); err != nil {
+		return &invalidChecker{
+			numArgs: 1,
+			err:     err,
+		}
+	}
+	return CmpEquals(cmp.Comparer(f))
+}
+
+// checkComparer checks that f is a non-nil func(x, y T) bool.
+func checkComparer(f interface{}) error {
+	t := reflect.TypeOf(f)
+	if t == nil || t.Kind() != reflect.Func {
+		return BadCheckf("invalid comparer: expected a func(x, y T) bool, got %T instead", f)
+	}
+	if t.NumIn() != 2 || t.IsVariadic() || t.NumOut() != 1 || t.Out(0) != reflect.TypeOf(true) {
+		return BadCheckf("invalid comparer: expected a func(x, y T) bool, got %s instead", t)
+	}
+	if reflect.ValueOf(f).IsNil() {
+		return BadCheckf("invalid comparer: nil %s function", t)
+	}
+	if t.In(0) != t.In(1) {
+		return BadCheckf("invalid comparer: arguments must be of the same type, got %s and %s", t.In(0), t.In(1))
+	}
+	return nil
+}
+
+// invalidChecker is a Checker which always fails with the stored error,
+// returned by checker constructors when provided invalid parameters.
+type invalidChecker struct {
+	numArgs
+	err error
+}
+
+// Check implements Checker.Check by returning the stored error.
+func (c *invalidChecker) Check(got interface{}, args []interface{}) error {
+	return c.err
+}
+
+// Negate implements Checker.Negate by returning the stored error.
+func (c *invalidChecker) Negate(got interface{}, args []interface{}) error {
+	return c.err
+}
+
+type cmpEqualsChecker struct {
+	numArgs
+	opts cmp.Options
+
+	// firstDiff holds whether only the first difference is reported.
+	firstDiff bool
+}
+
+// Check implements Checker.Check by checking that got == args[0] according to
+// the compare options stored in the checker.
+func (c *cmpEqualsChecker) Check(got interface{}, args []interface{}) (err error) {
+	defer func() {
+		// A panic is raised in some cases, for instance when trying to compare
+		// structs with unexported fields and neither AllowUnexported nor
+		// cmpopts.IgnoreUnexported are provided.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s", r)
+		}
+	}()
+	want := args[0]
+	if c.firstDiff {
+		r := &firstDiffReporter{}
+		if cmp.Equal(got, want, append(cmp.Options{cmp.Reporter(r)}, c.opts...)) {
+			return nil
+		}
+		return &notEqualError{
+			msg:  fmt.Sprintf("values are not equal (%s found), first difference at %s", &r.diffCounter, r.path),
+			got:  r.got,
+			want: r.want,
+		}
+	}
+	counter := &diffCounter{}
+	opts := append(cmp.Options{cmp.Reporter(counter)}, c.opts...)
+	if diff := Diff(got, want, opts); diff != "" {
+		return fmt.Errorf("values are not equal (%s found):\n%s%s", counter, notEqualErrorPrefix, diff)
+	}
+	return nil
+}
+
+// Diff returns a human readable report of the differences between got and
+// want, as included in CmpEquals and DeepEquals failure reports, using the
+// given compare options. An empty string is returned if the values are equal.
+// This can be used to build custom diagnostics, for instance:
+//
+//     if diff := qt.Diff(got, want); diff != "" {
+//         c.Logf("unexpected result (-got +want):\n%s", diff)
+//     }
+//
+// Like cmp.Diff, Diff panics if the values cannot be compared, for instance
+// when they include unexported fields and no options are provided to handle
+// them.
+func Diff(got, want interface{}, opts ...cmp.Option) string {
+	return strings.TrimSuffix(cmp.Diff(got, want, opts...), "\n")
+}
+
+// diffCounter is a cmp.Reporter counting the differences found when
+// comparing two values.
+type diffCounter struct {
+	n int
+}
+
+// PushStep implements cmp.Reporter.PushStep.
+func (r *diffCounter) PushStep(cmp.PathStep) {}
+
+// Report implements cmp.Reporter.Report by counting unequal results.
+func (r *diffCounter) Report(rs cmp.Result) {
+	if !rs.Equal() {
+		r.n++
+	}
+}
+
+// PopStep implements cmp.Reporter.PopStep.
+func (r *diffCounter) PopStep() {}
+
+// String returns the number of differences found in a human readable form.
+func (r *diffCounter) String() string {
+	if r.n == 1 {
+		return "1 difference"
+	}
+	return fmt.Sprintf("%d differences", r.n)
+}
+
+// firstDiffReporter is a cmp.Reporter recording the path and the values of
+// the first difference found when comparing two values, and counting all the
+// differences.
+type firstDiffReporter struct {
+	diffCounter
+	steps cmp.Path
+
+	path      string
+	got, want interface{}
+}
+
+// PushStep implements cmp.Reporter.PushStep.
+func (r *firstDiffReporter) PushStep(ps cmp.PathStep) {
+	r.steps = append(r.steps, ps)
+}
+
+// Report implements cmp.Reporter.Report by recording the first difference.
+func (r *firstDiffReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	r.n++
+	if r.n > 1 {
+		return
+	}
+	vx, vy := r.steps.Last().Values()
+	r.path, r.got, r.want = r.steps.GoString(), reportedValue(vx), reportedValue(vy)
+}
+
+// PopStep implements cmp.Reporter.PopStep.
+func (r *firstDiffReporter) PopStep() {
+	r.steps = r.steps[:len(r.steps)-1]
+}
+
+// reportedValue returns the value to be included in failure reports for the
+// given reflect value, which can be invalid when, for instance, a map key or a
+// slice element is only present on one side of a comparison.
+func reportedValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return missingValue{}
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return v
+}
+
+// missingValue is reported in place of a value not present on one side of a
+// comparison.
+type missingValue struct{}
+
+// GoString implements fmt.GoStringer.
+func (missingValue) GoString() string {
+	return "<missing>"
+}
+
+// Negate implements Checker.Negate by checking that got != args[0] according
+// to the compare options stored in the checker.
+func (c *cmpEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "both values deeply equal %#v", got)
+}
+
+// withOptions implements optionsChecker by returning a checker using the
+// given compare options in addition to the stored ones.
+func (c *cmpEqualsChecker) withOptions(opts []cmp.Option) (Checker, bool) {
+	allOpts := make(cmp.Options, 0, len(c.opts)+len(opts))
+	allOpts = append(allOpts, c.opts...)
+	allOpts = append(allOpts, opts...)
+	return &cmpEqualsChecker{
+		numArgs:   c.numArgs,
+		opts:      allOpts,
+		firstDiff: c.firstDiff,
+	}, true
+}
+
+// optionsChecker is implemented by checkers accepting additional compare
+// options when calling Check or Assert.
+type optionsChecker interface {
+	// withOptions returns a checker also using the given compare options.
+	// It returns false if the options cannot be applied.
+	withOptions(opts []cmp.Option) (Checker, bool)
+}
+
+// applyOptions returns a checker like the given one but also using the given
+// compare options. It returns false if the checker does not support them.
+func applyOptions(checker Checker, opts []cmp.Option) (Checker, bool) {
+	oc, ok := checker.(optionsChecker)
+	if !ok {
+		return nil, false
+	}
+	return oc.withOptions(opts)
+}
+
+// withCallOptions extracts the compare options provided right after the
+// checker arguments, and returns a checker using them together with the
+// remaining arguments. The checker and arguments are returned unchanged if the
+// checker does not support compare options.
+func withCallOptions(checker Checker, args []interface{}) (Checker, []interface{}) {
+	oc, ok := checker.(optionsChecker)
+	if !ok {
+		return checker, args
+	}
+	n := checker.NumArgs()
+	var opts []cmp.Option
+	for i := n; i < len(args); i++ {
+		opt, ok := args[i].(cmp.Option)
+		if !ok {
+			break
+		}
+		opts = append(opts, opt)
+	}
+	if len(opts) == 0 {
+		return checker, args
+	}
+	newChecker, ok := oc.withOptions(opts)
+	if !ok {
+		return checker, args
+	}
+	newArgs := make([]interface{}, 0, len(args)-len(opts))
+	newArgs = append(newArgs, args[:n]...)
+	newArgs = append(newArgs, args[n+len(opts):]...)
+	return newChecker, newArgs
+}
+
+// DeepEquals is a Checker deeply checking equality of two arbitrary values.
+// For instance:
+//
+//     c.Assert(got, qt.DeepEquals, []int{42, 47})
+//
+var DeepEquals = CmpEquals()
+
+// DeepEqualsFirstDiff is a Checker deeply checking equality of two arbitrary
+// values like DeepEquals, but reporting only the path and the values of the
+// first difference found, rather than the full diff. This is useful when
+// comparing big structures one difference at a time.
+// For instance:
+//
+//     c.Assert(got, qt.DeepEqualsFirstDiff, want)
+//
+// The failure output is similar to the following:
+//
+//     values are not equal (3 differences found), first difference at {*Person}.Name:
+//     (-got +want)
+//         -: "bad wolf"
+//         +: "dalek"
+//
+var DeepEqualsFirstDiff Checker = &cmpEqualsChecker{
+	numArgs:   1,
+	firstDiff: true,
+}
+
+// UnorderedEquals is a Checker checking that the provided slice or array has
+// the same elements as the expected one, regardless of their order. Elements
+// are compared using deep equality, and each element must appear the same
+// number of times in both values.
+// For instance:
+//
+//     c.Assert(names, qt.UnorderedEquals, []string{"who", "dalek", "who"})
+//
+var UnorderedEquals Checker = &unorderedEqualsChecker{
+	numArgs: 1,
+}
+
+type unorderedEqualsChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got and args[0] contain the
+// same elements, with the same multiplicities.
+func (c *unorderedEqualsChecker) Check(got interface{}, args []interface{}) error {
+	gotValue, want := reflect.ValueOf(got), reflect.ValueOf(args[0])
+	if k := gotValue.Kind(); k != reflect.Slice && k != reflect.Array {
+		return BadCheckf("expected a slice or an array, got %T instead", got)
+	}
+	if k := want.Kind(); k != reflect.Slice && k != reflect.Array {
+		return BadCheckf("expected value is of type %T, not a slice or an array", args[0])
+	}
+	// Remove each expected element from the remaining provided ones.
+	extra := make([]interface{}, gotValue.Len())
+	for i := range extra {
+		extra[i] = gotValue.Index(i).Interface()
+	}
+	var missing []interface{}
+	for i := 0; i < want.Len(); i++ {
+		elem := want.Index(i).Interface()
+		found := false
+		for j, e := range extra {
+			if deepEqual(e, elem) {
+				extra = append(extra[:j], extra[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, elem)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+	msg := "values do not contain the same elements:"
+	if len(missing) > 0 {
+		msg += "\n(missing)" + formatElements(missing)
+	}
+	if len(extra) > 0 {
+		msg += "\n(extra)" + formatElements(extra)
+	}
+	return errors.New(msg)
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] do not
+// contain the same elements.
+func (c *unorderedEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "values contain the same elements:\n(value)\n\t%#v", got)
+}
+
+// formatElements returns the given elements formatted one per line.
+func formatElements(elems []interface{}) string {
+	var buf bytes.Buffer
+	for _, elem := range elems {
+		fmt.Fprintf(&buf, "\n\t%#v", elem)
+	}
+	return buf.String()
+}
+
+// MatchesPartial returns a Checker checking that the provided struct, or
+// pointer to struct, has the same values as want in all the exported fields
+// that are not zero in want. Zero fields in want are ignored, so that only
+// the relevant fields need to be specified. Fields are compared using
+// go-cmp, and the first differing field is reported on failure.
+// For instance:
+//
+//     c.Assert(user, qt.MatchesPartial(User{Name: "Rose", Admin: true}))
+//
+// Note that a field cannot be checked to be zero with this checker, as zero
+// fields in want are always ignored.
+func MatchesPartial(want interface{}) Checker {
+	return &matchesPartialChecker{
+		want: want,
+	}
+}
+
+type matchesPartialChecker struct {
+	numArgs
+	want interface{}
+}
+
+// Check implements Checker.Check by checking that all the non-zero exported
+// fields of the stored struct are equal to the corresponding fields of got.
+func (c *matchesPartialChecker) Check(got interface{}, args []interface{}) error {
+	gotValue, wantValue := structValue(got), structValue(c.want)
+	if wantValue.Kind() != reflect.Struct {
+		return BadCheckf("expected value must be a struct or a pointer to struct, got %T instead", c.want)
+	}
+	if gotValue.Kind() != reflect.Struct {
+		return BadCheckf("expected a struct or a pointer to struct, got %T instead", got)
+	}
+	if gotValue.Type() != wantValue.Type() {
+		return BadCheckf("cannot compare %s with %s", gotValue.Type(), wantValue.Type())
+	}
+	for _, i := range c.fields(wantValue) {
+		gotField, wantField := gotValue.Field(i).Interface(), wantValue.Field(i).Interface()
+		if !deepEqual(gotField, wantField) {
+			return &notEqualError{
+				msg:  fmt.Sprintf("values are not equal in field %s", wantValue.Type().Field(i).Name),
+				got:  gotField,
+				want: wantField,
+			}
+		}
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that at least one of the
+// non-zero exported fields of the stored struct differs from the
+// corresponding field of got.
+func (c *matchesPartialChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "values are equal in all the specified fields:\n(fields)\n\t%s", strings.Join(c.fieldNames(), ", "))
+}
+
+// fieldNames returns the names of the fields compared by the checker, or nil
+// if the expected value is not a struct.
+func (c *matchesPartialChecker) fieldNames() []string {
+	wantValue := structValue(c.want)
+	if wantValue.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for _, i := range c.fields(wantValue) {
+		names = append(names, wantValue.Type().Field(i).Name)
+	}
+	return names
+}
+
+// fields returns the indexes of the exported fields of the given struct
+// value which are not zero.
+func (c *matchesPartialChecker) fields(v reflect.Value) []int {
+	var indexes []int
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath != "" {
+			// Unexported fields are ignored.
+			continue
+		}
+		f := v.Field(i)
+		if deepEqual(f.Interface(), reflect.Zero(f.Type()).Interface()) {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// FieldEquals returns a Checker checking that the named exported field of
+// the provided struct, or pointer to struct, is deeply equal to want. The
+// field name can be a dotted path, like "Address.City", to select a field of
+// a nested struct, in which case pointers to structs along the path are
+// dereferenced.
+// For instance:
+//
+//     c.Assert(resp, qt.FieldEquals("Header.Status", "OK"))
+//
+func FieldEquals(name string, want interface{}) Checker {
+	return &fieldEqualsChecker{
+		name: name,
+		want: want,
+	}
+}
+
+type fieldEqualsChecker struct {
+	numArgs
+	name string
+	want interface{}
+}
+
+// Check implements Checker.Check by checking that the stored field of got is
+// deeply equal to the stored value.
+func (c *fieldEqualsChecker) Check(got interface{}, args []interface{}) error {
+	field, err := c.field(got)
+	if err != nil {
+		return err
+	}
+	if !deepEqual(field, c.want) {
+		return &notEqualError{
+			msg:  fmt.Sprintf("values are not equal in field %s", c.name),
+			got:  field,
+			want: c.want,
+		}
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that the stored field of got
+// is not deeply equal to the stored value.
+func (c *fieldEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "field %s equals %#v", c.name, c.want)
+}
+
+// field returns the value of the stored field of the given struct.
+func (c *fieldEqualsChecker) field(got interface{}) (interface{}, error) {
+	v := structValue(got)
+	if v.Kind() != reflect.Struct {
+		return nil, BadCheckf("expected a struct or a pointer to struct, got %T instead", got)
+	}
+	names := strings.Split(c.name, ".")
+	for i, name := range names {
+		path := strings.Join(names[:i], ".")
+		if i > 0 {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return nil, fmt.Errorf("cannot get field %s: %s is nil", c.name, path)
+			}
+			v = reflect.Indirect(v)
+			if v.Kind() != reflect.Struct {
+				return nil, BadCheckf("cannot get field %s: %s is of type %s, not a struct", c.name, path, v.Type())
+			}
+		}
+		f, ok := v.Type().FieldByName(name)
+		if !ok {
+			return nil, BadCheckf("field %s not found in %s", name, v.Type())
+		}
+		if f.PkgPath != "" {
+			return nil, BadCheckf("field %s of %s is not exported", name, v.Type())
+		}
+		v = v.FieldByIndex(f.Index)
+	}
+	return v.Interface(), nil
+}
+
+// structValue returns the value of the given struct, dereferencing pointers
+// to structs. A pointer is returned as is if it is nil.
+func structValue(v interface{}) reflect.Value {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+		return rv.Elem()
+	}
+	return rv
+}
+
+// DeepEqualsApprox returns a Checker deeply checking equality of two
+// arbitrary values, considering float32 and float64 values equal when they
+// are within the given fraction or margin of each other. See
+// cmpopts.EquateApprox for details about how fraction and margin are used.
+// For instance:
+//
+//     c.Assert(point, qt.DeepEqualsApprox(0, 0.001), Point{X: 1.5, Y: 3})
+//
+func DeepEqualsApprox(fraction, margin float64) Checker {
+	return CmpEquals(cmpopts.EquateApprox(fraction, margin))
+}
+
+// FloatsClose returns a Checker checking that the provided []float64 value
+// has the same length as the expected one, and that each of its elements is
+// within the given tolerance of the corresponding expected element. Two NaN
+// elements are considered close. On failure, the first element exceeding
+// the tolerance is reported.
+// For instance:
+//
+//     c.Assert(result, qt.FloatsClose([]float64{0.5, 1.5}, 1e-9))
+//
+func FloatsClose(want []float64, tolerance float64) Checker {
+	return &floatsCloseChecker{
+		want:      want,
+		tolerance: tolerance,
+	}
+}
+
+type floatsCloseChecker struct {
+	numArgs
+	want      []float64
+	tolerance float64
+}
+
+// Check implements Checker.Check by checking that got is a []float64 whose
+// elements are close to the stored ones.
+func (c *floatsCloseChecker) Check(got interface{}, args []interface{}) error {
+	if c.tolerance < 0 || math.IsNaN(c.tolerance) {
+		return BadCheckf("invalid tolerance %v", c.tolerance)
+	}
+	values, ok := got.([]float64)
+	if !ok {
+		return BadCheckf("expected a []float64, got %T instead", got)
+	}
+	if len(values) != len(c.want) {
+		return fmt.Errorf("slices have different lengths:\n(-got length +want length)\n\t-: %d\n\t+: %d\n(-got +want)\n\t-: %v\n\t+: %v", len(values), len(c.want), values, c.want)
+	}
+	for i, v := range values {
+		w := c.want[i]
+		if math.IsNaN(v) && math.IsNaN(w) {
+			continue
+		}
+		// Use a negated comparison so that NaN deltas are not considered close.
+		if delta := math.Abs(v - w); !(delta <= c.tolerance) {
+			return fmt.Errorf("slices are not close within a tolerance of %v, first difference at index %d:\n(-got +want)\n\t-: %v\n\t+: %v\n(difference)\n\t%v", c.tolerance, i, v, w, delta)
+		}
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is a []float64 whose
+// elements are not all close to the stored ones.
+func (c *floatsCloseChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "slices are close within a tolerance of %v:\n(-got +want)\n\t-: %v\n\t+: %v", c.tolerance, got, c.want)
+}
+
+// WithinPercent returns a Checker checking that the provided number is within
+// the given percentage of want, so that the absolute difference between the
+// two values is not greater than percent/100 times the absolute value of want.
+// The provided value can be of any integer or floating point type.
+// For instance:
+//
+//     c.Assert(hitRatio, qt.WithinPercent(0.8, 5))
+//     c.Assert(len(sampled), qt.WithinPercent(1000, 10))
+//
+func WithinPercent(want, percent float64) Checker {
+	return &withinPercentChecker{
+		want:    want,
+		percent: percent,
+	}
+}
+
+type withinPercentChecker struct {
+	numArgs
+	want    float64
+	percent float64
+}
+
+// Check implements Checker.Check by checking that got is a number within the
+// stored percentage of the expected value.
+func (c *withinPercentChecker) Check(got interface{}, args []interface{}) error {
+	if c.percent < 0 || math.IsNaN(c.percent) || math.IsNaN(c.want) || math.IsInf(c.want, 0) {
+		return BadCheckf("invalid percentage band %v%% around %v", c.percent, c.want)
+	}
+	f, ok := bigFloat(got)
+	if !ok {
+		if isNaN(got) {
+			return fmt.Errorf("value is not within %v%% of %v:\n(value)\n\t%v", c.percent, c.want, got)
+		}
+		return BadCheckf("expected a numeric value, got %T instead", got)
+	}
+	v, _ := f.Float64()
+	if math.Abs(v-c.want) <= c.band() {
+		return nil
+	}
+	return fmt.Errorf(
+		"value is not within %v%% of %v:\n%s\t-: %v\n\t+: %v\n(allowed range)\n\t%s\n(deviation)\n\t%s",
+		c.percent, c.want, notEqualErrorPrefix, got, c.want, c.rangeString(), c.deviation(v))
+}
+
+// Negate implements Checker.Negate by checking that got is a number outside
+// the stored percentage of the expected value.
+func (c *withinPercentChecker) Negate(got interface{}, args []interface{}) error {
+	var v float64
+	if f, ok := bigFloat(got); ok {
+		v, _ = f.Float64()
+	}
+	return NegateCheckf(c.Check(got, args),
+		"value is within %v%% of %v:\n%s\t-: %v\n\t+: %v\n(allowed range)\n\t%s\n(deviation)\n\t%s",
+		c.percent, c.want, notEqualErrorPrefix, got, c.want, c.rangeString(), c.deviation(v))
+}
+
+// band returns the maximum allowed absolute difference from the expected
+// value.
+func (c *withinPercentChecker) band() float64 {
+	return math.Abs(c.want) * c.percent / 100
+}
+
+// rangeString returns the allowed range in interval notation.
+func (c *withinPercentChecker) rangeString() string {
+	return fmt.Sprintf("[%v, %v]", c.want-c.band(), c.want+c.band())
+}
+
+// deviation returns the deviation of v from the expected value as a
+// percentage of the expected value.
+func (c *withinPercentChecker) deviation(v float64) string {
+	if c.want == 0 {
+		if v == 0 {
+			return "0%"
+		}
+		return "infinite (expected value is zero)"
+	}
+	return fmt.Sprintf("%.4g%%", math.Abs(v-c.want)/math.Abs(c.want)*100)
+}
+
+// ComplexClose returns a Checker checking that the provided complex number is
+// within the given tolerance of want, so that the magnitude of their
+// difference, |got-want|, is not greater than the tolerance. Both complex128
+// and complex64 values are accepted, the latter being converted to complex128.
+// For instance:
+//
+//     c.Assert(spectrum[3], qt.ComplexClose(complex(0, -4), 1e-9))
+//
+func ComplexClose(want complex128, tolerance float64) Checker {
+	return &complexCloseChecker{
+		want:      want,
+		tolerance: tolerance,
+	}
+}
+
+type complexCloseChecker struct {
+	numArgs
+	want      complex128
+	tolerance float64
+}
+
+// Check implements Checker.Check by checking that got is a complex number
+// within the stored tolerance of the expected value.
+func (c *complexCloseChecker) Check(got interface{}, args []interface{}) error {
+	v, diff, err := c.diff(got)
+	if err != nil {
+		return err
+	}
+	if diff <= c.tolerance {
+		return nil
+	}
+	return fmt.Errorf(
+		"complex numbers are not within %v of each other:\n%s\t-: %v\n\t+: %v\n(|got-want|)\n\t%v",
+		c.tolerance, notEqualErrorPrefix, v, c.want, diff)
+}
+
+// Negate implements Checker.Negate by checking that got is a complex number
+// not within the stored tolerance of the expected value.
+func (c *complexCloseChecker) Negate(got interface{}, args []interface{}) error {
+	v, diff, err := c.diff(got)
+	if err != nil {
+		return err
+	}
+	if !(diff <= c.tolerance) {
+		return nil
+	}
+	return fmt.Errorf(
+		"complex numbers are within %v of each other, but should not:\n%s\t-: %v\n\t+: %v\n(|got-want|)\n\t%v",
+		c.tolerance, notEqualErrorPrefix, v, c.want, diff)
+}
+
+// diff returns got as a complex128, and the magnitude of its difference from
+// the expected value.
+func (c *complexCloseChecker) diff(got interface{}) (complex128, float64, error) {
+	if c.tolerance < 0 || math.IsNaN(c.tolerance) {
+		return 0, 0, BadCheckf("invalid tolerance %v", c.tolerance)
+	}
+	var v complex128
+	switch got := got.(type) {
+	case complex128:
+		v = got
+	case complex64:
+		v = complex128(got)
+	default:
+		return 0, 0, BadCheckf("expected a complex number, got %T instead", got)
+	}
+	return v, cmplx.Abs(v - c.want), nil
+}
+
+// TimeEquals returns a Checker checking that the provided time.Time value is
+// within the given tolerance of the expected time.
+// For instance:
+//
+//     c.Assert(got, qt.TimeEquals(want, time.Millisecond))
+//
+func TimeEquals(want time.Time, tolerance time.Duration) Checker {
+	return &timeEqualsChecker{
+		want:      want,
+		tolerance: tolerance,
+	}
+}
+
+type timeEqualsChecker struct {
+	numArgs
+	want      time.Time
+	tolerance time.Duration
+}
+
+// Check implements Checker.Check by checking that got is a time.Time whose
+// distance from the expected time is not greater than the tolerance.
+func (c *timeEqualsChecker) Check(got interface{}, args []interface{}) error {
+	t, ok := got.(time.Time)
+	if !ok {
+		return BadCheckf("expected a time.Time, got %T instead", got)
+	}
+	if diff := absDuration(t.Sub(c.want)); diff > c.tolerance {
+		return fmt.Errorf(
+			"times are not equal within a tolerance of %v:\n%s\t-: %s\n\t+: %s\n(difference)\n\t%v",
+			c.tolerance, notEqualErrorPrefix, t.Format(time.RFC3339Nano), c.want.Format(time.RFC3339Nano), diff)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is a time.Time whose
+// distance from the expected time is greater than the tolerance.
+func (c *timeEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	t, _ := got.(time.Time)
+	return NegateCheckf(c.Check(got, args),
+		"times are equal within a tolerance of %v:\n(got)\n\t%s\n(want)\n\t%s\n(difference)\n\t%v",
+		c.tolerance, t.Format(time.RFC3339Nano), c.want.Format(time.RFC3339Nano), absDuration(t.Sub(c.want)))
+}
+
+// TimeEqualsRounded returns a Checker checking that the provided time.Time
+// value is equal to the expected time when both are rounded to the given unit.
+// Unlike TimeEquals, times close to each other but on different sides of a
+// rounding boundary are not considered equal. This matches how many systems
+// store timestamps at a reduced precision.
+// For instance:
+//
+//     c.Assert(stored.CreatedAt, qt.TimeEqualsRounded(want, time.Millisecond))
+//
+// On failure, both the rounded and the original times are reported.
+func TimeEqualsRounded(want time.Time, unit time.Duration) Checker {
+	return &timeEqualsRoundedChecker{
+		want: want,
+		unit: unit,
+	}
+}
+
+type timeEqualsRoundedChecker struct {
+	numArgs
+	want time.Time
+	unit time.Duration
+}
+
+// Check implements Checker.Check by checking that got is a time.Time equal to
+// the expected time when both are rounded to the unit.
+func (c *timeEqualsRoundedChecker) Check(got interface{}, args []interface{}) error {
+	t, rounded, err := c.round(got)
+	if err != nil {
+		return err
+	}
+	if want := c.want.Round(c.unit); !rounded.Equal(want) {
+		return fmt.Errorf(
+			"times are not equal when rounded to %v:\n%s\t-: %s\n\t+: %s\n(original got)\n\t%s\n(original want)\n\t%s",
+			c.unit, notEqualErrorPrefix, rounded.Format(time.RFC3339Nano), want.Format(time.RFC3339Nano), t.Format(time.RFC3339Nano), c.want.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is a time.Time not
+// equal to the expected time when both are rounded to the unit.
+func (c *timeEqualsRoundedChecker) Negate(got interface{}, args []interface{}) error {
+	t, rounded, _ := c.round(got)
+	return NegateCheckf(c.Check(got, args),
+		"times are equal when rounded to %v:\n(rounded)\n\t%s\n(original got)\n\t%s\n(original want)\n\t%s",
+		c.unit, rounded.Format(time.RFC3339Nano), t.Format(time.RFC3339Nano), c.want.Format(time.RFC3339Nano))
+}
+
+// round returns got as a time, and the time rounded to the unit.
+func (c *timeEqualsRoundedChecker) round(got interface{}) (t, rounded time.Time, err error) {
+	if c.unit <= 0 {
+		return t, rounded, BadCheckf("invalid rounding unit %v", c.unit)
+	}
+	t, ok := got.(time.Time)
+	if !ok {
+		return t, rounded, BadCheckf("expected a time.Time, got %T instead", got)
+	}
+	return t, t.Round(c.unit), nil
+}
+
+// DurationEquals is a Checker checking equality of two time.Duration values.
+// On failure, durations are reported in their human readable form, like
+// "1.5s", rather than as a number of nanoseconds.
+// For instance:
+//
+//     c.Assert(timeout, qt.DurationEquals, 1500*time.Millisecond)
+//
+var DurationEquals Checker = &durationEqualsChecker{
+	numArgs: 1,
+}
+
+// DurationEqualsApprox returns a Checker checking that the provided
+// time.Duration value is within the given tolerance of the expected one.
+// For instance:
+//
+//     c.Assert(elapsed, qt.DurationEqualsApprox(10*time.Millisecond), time.Second)
+//
+func DurationEqualsApprox(tolerance time.Duration) Checker {
+	return &durationEqualsChecker{
+		numArgs:   1,
+		tolerance: tolerance,
+	}
+}
+
+type durationEqualsChecker struct {
+	numArgs
+	tolerance time.Duration
+}
+
+// Check implements Checker.Check by checking that got and args[0] are
+// durations whose difference is not greater than the tolerance.
+func (c *durationEqualsChecker) Check(got interface{}, args []interface{}) error {
+	d, want, err := durations(got, args[0])
+	if err != nil {
+		return err
+	}
+	diff := absDuration(d - want)
+	if diff <= c.tolerance {
+		return nil
+	}
+	msg := "durations are not equal"
+	if c.tolerance != 0 {
+		msg = fmt.Sprintf("durations are not equal within a tolerance of %v", c.tolerance)
+	}
+	return fmt.Errorf("%s:\n%s\t-: %v\n\t+: %v\n(difference)\n\t%v", msg, notEqualErrorPrefix, d, want, diff)
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] are
+// durations whose difference is greater than the tolerance.
+func (c *durationEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	d, want, _ := durations(got, args[0])
+	if c.tolerance == 0 {
+		return NegateCheckf(c.Check(got, args), "both durations equal %v", d)
+	}
+	return NegateCheckf(c.Check(got, args),
+		"durations are equal within a tolerance of %v:\n(got)\n\t%v\n(want)\n\t%v\n(difference)\n\t%v",
+		c.tolerance, d, want, absDuration(d-want))
+}
+
+// durations returns the given got and want values as durations.
+func durations(got, want interface{}) (time.Duration, time.Duration, error) {
+	d, ok := got.(time.Duration)
+	if !ok {
+		return 0, 0, BadCheckf("expected a time.Duration, got %T instead", got)
+	}
+	w, ok := want.(time.Duration)
+	if !ok {
+		return 0, 0, BadCheckf("expected value is of type %T, not time.Duration", want)
+	}
+	return d, w, nil
+}
+
+// absDuration returns the absolute value of the given duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// BytesEquals is a Checker checking equality of two byte slices. On failure,
+// a hex dump of both values is reported, with the first difference marked.
+// For instance:
+//
+//     c.Assert(encoded, qt.BytesEquals, []byte{0xca, 0xfe})
+//
+var BytesEquals Checker = &bytesEqualsChecker{
+	numArgs: 1,
+}
+
+type bytesEqualsChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got and args[0] are byte
+// slices with the same contents.
+func (c *bytesEqualsChecker) Check(got interface{}, args []interface{}) error {
+	gotBytes, ok := got.([]byte)
+	if !ok {
+		return BadCheckf("expected a []byte, got %T instead", got)
+	}
+	wantBytes, ok := args[0].([]byte)
+	if !ok {
+		return BadCheckf("expected value is of type %T, not []byte", args[0])
+	}
+	if bytes.Equal(gotBytes, wantBytes) {
+		return nil
+	}
+	return &bytesNotEqualError{
+		msg:  "byte slices are not equal",
+		got:  gotBytes,
+		want: wantBytes,
+	}
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] are byte
+// slices with different contents.
+func (c *bytesEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	b, _ := got.([]byte)
+	return NegateCheckf(c.Check(got, args), "byte slices are equal:\n(value)\n%s", hexDump(b, "\t"))
+}
+
+// ReaderYields returns a Checker checking that the provided io.Reader
+// produces exactly the given bytes before returning io.EOF. On content
+// mismatch, a hex dump of both the read and expected data is reported.
+// For instance:
+//
+//     c.Assert(gzip.NewReader(r), qt.ReaderYields([]byte("bad wolf")))
+//
+// At most len(want)+1024 bytes are read, so that readers producing an endless
+// stream of data do not cause the check to hang.
+func ReaderYields(want []byte) Checker {
+	return &readerYieldsChecker{
+		want: want,
+	}
+}
+
+type readerYieldsChecker struct {
+	numArgs
+	want []byte
+}
+
+// maxReaderExtraBytes holds the number of bytes read by ReaderYields past
+// the expected data.
+const maxReaderExtraBytes = 1024
+
+// Check implements Checker.Check by checking that got is an io.Reader
+// producing the stored bytes.
+func (c *readerYieldsChecker) Check(got interface{}, args []interface{}) error {
+	r, ok := got.(io.Reader)
+	if !ok {
+		return BadCheckf("expected an io.Reader, got %T instead", got)
+	}
+	limit := int64(len(c.want) + maxReaderExtraBytes)
+	data, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return fmt.Errorf("cannot read from reader after %d bytes:\n(error)\n\t%s", len(data), err)
+	}
+	var truncated bool
+	if int64(len(data)) > limit {
+		data, truncated = data[:limit], true
+	}
+	if !truncated && bytes.Equal(data, c.want) {
+		return nil
+	}
+	err = &bytesNotEqualError{
+		msg:  "reader produced unexpected data",
+		got:  data,
+		want: c.want,
+	}
+	if truncated {
+		return fmt.Errorf("%s\n(truncated)\n\treading stopped after %d bytes, more data is available", err, limit)
+	}
+	return err
+}
+
+// Negate implements Checker.Negate by checking that got is an io.Reader not
+// producing the stored bytes.
+func (c *readerYieldsChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "reader produced the expected data:\n(value)\n%s", hexDump(c.want, "\t"))
+}
+
+// ReaderFailsWith returns a Checker checking that reading from the provided
+// io.Reader eventually fails with an error whose message matches the given
+// regular expression pattern. The reader is consumed until it returns an
+// error, so it must not produce an endless stream of data. Reaching io.EOF
+// is never considered a match, as it means the reader did not fail.
+// For instance:
+//
+//     c.Assert(gzip.NewReader(truncated), qt.ReaderFailsWith("unexpected EOF"))
+//
+// The number of bytes read before the error is included in failure reports.
+func ReaderFailsWith(pattern string) Checker {
+	return &readerFailsWithChecker{
+		pattern: pattern,
+	}
+}
+
+type readerFailsWithChecker struct {
+	numArgs
+	pattern string
+}
+
+// Check implements Checker.Check by checking that got is an io.Reader
+// failing with an error matching the stored pattern.
+func (c *readerFailsWithChecker) Check(got interface{}, args []interface{}) error {
+	n, readErr, err := c.read(got)
+	if err != nil {
+		return err
+	}
+	if readErr == nil {
+		return fmt.Errorf("reader reached EOF without failing:\n(bytes read)\n\t%d\n(pattern)\n\t%q", n, c.pattern)
+	}
+	if err := match(readErr.Error(), c.pattern, "reader error mismatch"); err != nil {
+		return fmt.Errorf("%s\n(bytes read)\n\t%d", err, n)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is an io.Reader
+// reaching EOF or failing with an error not matching the stored pattern.
+func (c *readerFailsWithChecker) Negate(got interface{}, args []interface{}) error {
+	n, readErr, err := c.read(got)
+	if err != nil {
+		return err
+	}
+	if readErr == nil || match(readErr.Error(), c.pattern, "") != nil {
+		return nil
+	}
+	return fmt.Errorf("reader error matches the pattern, but should not:\n(error)\n\t%q\n(pattern)\n\t%q\n(bytes read)\n\t%d", readErr, c.pattern, n)
+}
+
+// read consumes the given reader, and returns the number of bytes read and
+// the error returned by the reader, or nil if the reader reached io.EOF.
+// A bad check error is returned if got is not a reader or if the stored
+// pattern is not a valid regular expression.
+func (c *readerFailsWithChecker) read(got interface{}) (n int64, readErr, err error) {
+	if _, err := regexp.Compile("^(" + c.pattern + ")$"); err != nil {
+		return 0, nil, BadCheckf("cannot compile regular expression %q: %s", c.pattern, err)
+	}
+	r, ok := got.(io.Reader)
+	if !ok {
+		return 0, nil, BadCheckf("expected an io.Reader, got %T instead", got)
+	}
+	n, readErr = io.Copy(ioutil.Discard, r)
+	return n, readErr, nil
+}
+
+// ReadersEqual is a Checker checking that the provided io.Reader produces the
+// same data as the expected io.Reader. The readers are compared chunk by
+// chunk, so that large streams can be compared without reading them fully
+// into memory.
+// For instance:
+//
+//     c.Assert(newEncoder(input), qt.ReadersEqual, referenceEncoder(input))
+//
+// On failure, the offset of the first differing byte is reported, or the
+// offset at which one of the readers ended early.
+var ReadersEqual Checker = &readersEqualChecker{
+	numArgs: 1,
+}
+
+type readersEqualChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got and args[0] are
+// readers producing the same data.
+func (c *readersEqualChecker) Check(got interface{}, args []interface{}) error {
+	_, err := compareReaders(got, args[0])
+	return err
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] are
+// readers producing different data.
+func (c *readersEqualChecker) Negate(got interface{}, args []interface{}) error {
+	n, err := compareReaders(got, args[0])
+	return NegateCheckf(err, "readers produce the same data:\n(length)\n\t%d bytes", n)
+}
+
+// readersChunkSize holds the number of bytes read at a time from each reader
+// by ReadersEqual. It is a multiple of the hex dump row size, so that rows
+// are never split between chunks.
+const readersChunkSize = 32 * 1024
+
+// compareReaders compares the data produced by the given readers, and returns
+// the number of bytes read from each of them.
+func compareReaders(got, want interface{}) (int64, error) {
+	g, ok := got.(io.Reader)
+	if !ok {
+		return 0, BadCheckf("expected an io.Reader, got %T instead", got)
+	}
+	w, ok := want.(io.Reader)
+	if !ok {
+		return 0, BadCheckf("expected value is of type %T, not io.Reader", want)
+	}
+	gbuf, wbuf := make([]byte, readersChunkSize), make([]byte, readersChunkSize)
+	var offset int64
+	for {
+		gn, err := io.ReadFull(g, gbuf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return offset, fmt.Errorf("cannot read from reader after %d bytes:\n(error)\n\t%s", offset+int64(gn), err)
+		}
+		wn, err := io.ReadFull(w, wbuf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return offset, fmt.Errorf("cannot read from expected reader after %d bytes:\n(error)\n\t%s", offset+int64(wn), err)
+		}
+		i := 0
+		for i < gn && i < wn && gbuf[i] == wbuf[i] {
+			i++
+		}
+		row := i - i%hexDumpRowSize
+		if i < gn && i < wn {
+			return offset + int64(i), fmt.Errorf(
+				"readers differ at byte offset %d (0x%x):\n%s\t-: %s\n\t+: %s\n\t   %s^^",
+				offset+int64(i), offset+int64(i), notEqualErrorPrefix,
+				hexDumpLine(int(offset)+row, bytesRow(gbuf[:gn], row)),
+				hexDumpLine(int(offset)+row, bytesRow(wbuf[:wn], row)),
+				strings.Repeat(" ", hexDumpColumn(i-row)))
+		}
+		if gn < wn {
+			return offset + int64(gn), fmt.Errorf(
+				"reader ended early after %d bytes, while the expected reader has more data:\n(next expected bytes)\n\t%s",
+				offset+int64(gn), hexDumpLine(int(offset)+row, bytesRow(wbuf[:wn], row)))
+		}
+		if gn > wn {
+			return offset + int64(wn), fmt.Errorf(
+				"expected reader ended early after %d bytes, while the reader has more data:\n(next bytes)\n\t%s",
+				offset+int64(wn), hexDumpLine(int(offset)+row, bytesRow(gbuf[:gn], row)))
+		}
+		offset += int64(gn)
+		if gn < readersChunkSize {
+			return offset, nil
+		}
+	}
+}
+
+// RoundTrips returns a Checker checking that the provided value, when encoded
+// with the given marshal function and then decoded with the given unmarshal
+// function into a new value of the same type, results in a value deeply equal
+// to the original one.
+// For instance:
+//
+//     c.Assert(msg, qt.RoundTrips(proto.Marshal, proto.Unmarshal))
+//
+// See JSONRoundTrips for a checker using the encoding/json package.
+func RoundTrips(marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error) Checker {
+	return &roundTripsChecker{
+		marshal:   marshal,
+		unmarshal: unmarshal,
+	}
+}
+
+// JSONRoundTrips is a Checker checking that the provided value is preserved
+// when encoded to JSON and decoded back.
+// For instance:
+//
+//     c.Assert(params, qt.JSONRoundTrips)
+//
+var JSONRoundTrips = RoundTrips(json.Marshal, json.Unmarshal)
+
+type roundTripsChecker struct {
+	numArgs
+	marshal   func(interface{}) ([]byte, error)
+	unmarshal func([]byte, interface{}) error
+}
+
+// Check implements Checker.Check by checking that got is deeply equal to the
+// value obtained by encoding and then decoding it.
+func (c *roundTripsChecker) Check(got interface{}, args []interface{}) error {
+	if got == nil {
+		return BadCheckf("cannot round trip a nil value")
+	}
+	data, err := c.marshal(got)
+	if err != nil {
+		return fmt.Errorf("cannot marshal value: %s\n(value)\n\t%#v", err, got)
+	}
+	decoded := reflect.New(reflect.TypeOf(got))
+	if err := c.unmarshal(data, decoded.Interface()); err != nil {
+		return fmt.Errorf("cannot unmarshal value: %s\n(value)\n\t%#v\n(encoded)\n\t%q", err, got, data)
+	}
+	if result := decoded.Elem().Interface(); !deepEqual(got, result) {
+		return fmt.Errorf("value does not round trip:\n(encoded)\n\t%q\n%s", data, valuesDiff(got, result, "original", "decoded"))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is not preserved by
+// encoding and then decoding it.
+func (c *roundTripsChecker) Negate(got interface{}, args []interface{}) error {
+	data, _ := c.marshal(got)
+	return NegateCheckf(c.Check(got, args), "value round trips:\n(value)\n\t%#v\n(encoded)\n\t%q", got, data)
+}
+
+// SameBehavior returns a Checker checking that the provided function returns
+// the same results as the given reference function for each of the given
+// inputs. Both functions must have the same type, and results are compared
+// using deep equality, so that errors with the same dynamic type and contents
+// are considered equal. Panics are recovered and are also part of the compared
+// behavior. For functions accepting a single argument, each input
+// is the argument. For functions accepting multiple arguments, each input
+// must be a []interface{} holding the arguments.
+// For instance:
+//
+//     c.Assert(newParse, qt.SameBehavior(oldParse, []interface{}{
+//         "", "42", "-1", "bad wolf",
+//     }))
+//
+// On failure, the first input for which the results diverge is reported
+// along with both results.
+func SameBehavior(reference interface{}, inputs []interface{}) Checker {
+	return &sameBehaviorChecker{
+		reference: reference,
+		inputs:    inputs,
+	}
+}
+
+type sameBehaviorChecker struct {
+	numArgs
+	reference interface{}
+	inputs    []interface{}
+}
+
+// Check implements Checker.Check by checking that got and the reference
+// function return the same results for all the stored inputs.
+func (c *sameBehaviorChecker) Check(got interface{}, args []interface{}) error {
+	f, ref := reflect.ValueOf(got), reflect.ValueOf(c.reference)
+	if ref.Kind() != reflect.Func {
+		return BadCheckf("reference must be a function, got %T instead", c.reference)
+	}
+	if f.Kind() != reflect.Func {
+		return BadCheckf("expected a function, got %T instead", got)
+	}
+	if f.Type() != ref.Type() {
+		return BadCheckf("function signatures do not match: got %s, reference %s", f.Type(), ref.Type())
+	}
+	ftype := f.Type()
+	if ftype.NumIn() == 0 || ftype.IsVariadic() {
+		return BadCheckf("expected a non-variadic function accepting at least one argument, got %s instead", ftype)
+	}
+	for i, input := range c.inputs {
+		in, err := callArgs(ftype, input)
+		if err != nil {
+			return BadCheckf("invalid input %d: %s", i, err)
+		}
+		gotResults, gotPanic, gotPanicked := callRecover(f, in)
+		refResults, refPanic, refPanicked := callRecover(ref, in)
+		header := fmt.Sprintf("functions behave differently for input %d:\n(input)\n\t%#v", i, input)
+		switch {
+		case gotPanicked && refPanicked:
+			if !deepEqual(gotPanic, refPanic) {
+				return fmt.Errorf("%s\n(got panic)\n\t%v\n(reference panic)\n\t%v", header, gotPanic, refPanic)
+			}
+		case gotPanicked:
+			return fmt.Errorf("%s\n(got panic)\n\t%v\n(reference results)\n\t%v", header, gotPanic, refResults)
+		case refPanicked:
+			return fmt.Errorf("%s\n(got results)\n\t%v\n(reference panic)\n\t%v", header, gotResults, refPanic)
+		case !deepEqual(gotResults, refResults):
+			return fmt.Errorf("%s\n%s", header, resultsDiff(gotResults, refResults))
+		}
+	}
+	return nil
+}
+
+// callRecover calls the given function with the given arguments like
+// callResults, but also recovers from panics, returning the recovered value
+// and true if the function panicked.
+func callRecover(f reflect.Value, in []reflect.Value) (results []interface{}, recovered interface{}, panicked bool) {
+	defer func() {
+		if panicked {
+			recovered = recover()
+		}
+	}()
+	panicked = true
+	results = callResults(f, in)
+	return results, nil, false
+}
+
+// resultsDiff returns the go-cmp diff between the given results or, if go-cmp
+// cannot handle them, for instance because they include errors with unexported
+// fields, both results formatted as plain values.
+func resultsDiff(got, reference []interface{}) (diff string) {
+	defer func() {
+		if r := recover(); r != nil {
+			diff = fmt.Sprintf("(got results)\n\t%v\n(reference results)\n\t%v", got, reference)
+		}
+	}()
+	return "(-got +reference)\n" + strings.TrimSuffix(cmp.Diff(got, reference), "\n")
+}
+
+// valuesDiff returns the go-cmp diff between x and y, labelled with the given
+// names or, if go-cmp cannot handle the values, both values formatted with %#v.
+func valuesDiff(x, y interface{}, xname, yname string) (diff string) {
+	defer func() {
+		if r := recover(); r != nil {
+			diff = fmt.Sprintf("(%s)\n\t%#v\n(%s)\n\t%#v", xname, x, yname, y)
+		}
+	}()
+	return fmt.Sprintf("(-%s +%s)\n", xname, yname) + strings.TrimSuffix(cmp.Diff(x, y), "\n")
+}
+
+// Negate implements Checker.Negate by checking that got and the reference
+// function return different results for at least one of the stored inputs.
+func (c *sameBehaviorChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "functions behave the same for all %d inputs", len(c.inputs))
+}
+
+// callArgs returns the arguments for calling a function of the given type
+// with the given input.
+func callArgs(ftype reflect.Type, input interface{}) ([]reflect.Value, error) {
+	values := []interface{}{input}
+	if ftype.NumIn() > 1 {
+		var ok bool
+		if values, ok = input.([]interface{}); !ok {
+			return nil, fmt.Errorf("expected a []interface{} holding %d arguments, got %T", ftype.NumIn(), input)
+		}
+		if len(values) != ftype.NumIn() {
+			return nil, fmt.Errorf("expected %d arguments, got %d", ftype.NumIn(), len(values))
+		}
+	}
+	in := make([]reflect.Value, len(values))
+	for i, v := range values {
+		argType := ftype.In(i)
+		if v == nil {
+			switch argType.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+				in[i] = reflect.Zero(argType)
+				continue
+			}
+			return nil, fmt.Errorf("cannot use nil as argument of type %s", argType)
+		}
+		in[i] = reflect.ValueOf(v)
+		if !in[i].Type().AssignableTo(argType) {
+			return nil, fmt.Errorf("cannot use %T as argument of type %s", v, argType)
+		}
+	}
+	return in, nil
+}
+
+// callResults calls the given function with the given arguments, and returns
+// its results.
+func callResults(f reflect.Value, in []reflect.Value) []interface{} {
+	out := f.Call(in)
+	results := make([]interface{}, len(out))
+	for i, v := range out {
+		results[i] = v.Interface()
+	}
+	return results
+}
+
+// Matches is a Checker checking that the provided string, or the string
+// representation of the provided value, matches the provided regular
+// expression pattern.
+// For instance:
+//
+//     c.Assert("these are the voyages", qt.Matches, "these are .*")
+//     c.Assert(net.ParseIP("1.2.3.4"), qt.Matches, "1.*")
+//
+var Matches Checker = &matchesChecker{
+	numArgs: 1,
+}
+
+type matchesChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is a string or a
+// fmt.Stringer and that it matches args[0].
+func (c *matchesChecker) Check(got interface{}, args []interface{}) error {
+	pattern := args[0]
+	switch v := got.(type) {
+	case string:
+		return match(v, pattern, "string mismatch")
+	case fmt.Stringer:
+		return match(v.String(), pattern, "fmt.Stringer mismatch")
+	}
+	return BadCheckf("did not get an string or a fmt.Stringer, got %T instead", got)
+}
+
+// Negate implements Checker.Negate by checking that got is a string or a
+// fmt.Stringer and that it does not match args[0].
+func (c *matchesChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "%q matches %q", got, args[0])
+}
+
+// ContainsAll returns a Checker checking that the provided string contains
+// all the given substrings. This is useful for instance to check that log
+// output or rendered templates include several fragments.
+// For instance:
+//
+//     c.Assert(page, qt.ContainsAll("<title>", "bad wolf", "</html>"))
+//
+// On failure, the substrings that are missing are reported.
+func ContainsAll(subs ...string) Checker {
+	return &containsSubstringsChecker{
+		subs: subs,
+	}
+}
+
+// ContainsAny returns a Checker checking that the provided string contains at
+// least one of the given substrings.
+// For instance:
+//
+//     c.Assert(err.Error(), qt.ContainsAny("timeout", "deadline exceeded"))
+//
+func ContainsAny(subs ...string) Checker {
+	return &containsSubstringsChecker{
+		subs: subs,
+		any:  true,
+	}
+}
+
+type containsSubstringsChecker struct {
+	numArgs
+	subs []string
+	any  bool
+}
+
+// Check implements Checker.Check by checking that got contains all the stored
+// substrings, or at least one of them.
+func (c *containsSubstringsChecker) Check(got interface{}, args []interface{}) error {
+	found, missing, err := c.find(got)
+	if err != nil {
+		return err
+	}
+	if c.any && len(found) == 0 {
+		return fmt.Errorf("string does not contain any of the given substrings:\n(value)\n\t%q\n(substrings)%s", got, formatSubstrings(c.subs))
+	}
+	if !c.any && len(missing) != 0 {
+		return fmt.Errorf("string does not contain all the given substrings:\n(value)\n\t%q\n(missing)%s", got, formatSubstrings(missing))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got does not contain all
+// the stored substrings, or does not contain any of them.
+func (c *containsSubstringsChecker) Negate(got interface{}, args []interface{}) error {
+	found, missing, err := c.find(got)
+	if err != nil {
+		return err
+	}
+	if c.any && len(found) != 0 {
+		return fmt.Errorf("string contains some of the given substrings, but should not:\n(value)\n\t%q\n(found)%s", got, formatSubstrings(found))
+	}
+	if !c.any && len(missing) == 0 {
+		return fmt.Errorf("string contains all the given substrings, but should not:\n(value)\n\t%q\n(substrings)%s", got, formatSubstrings(c.subs))
+	}
+	return nil
+}
+
+// find returns the stored substrings that are found in got, and the ones that
+// are missing.
+func (c *containsSubstringsChecker) find(got interface{}) (found, missing []string, err error) {
+	if len(c.subs) == 0 {
+		return nil, nil, BadCheckf("no substrings provided")
+	}
+	s, ok := got.(string)
+	if !ok {
+		return nil, nil, BadCheckf("expected a string, got %T instead", got)
+	}
+	for _, sub := range c.subs {
+		if strings.Contains(s, sub) {
+			found = append(found, sub)
+		} else {
+			missing = append(missing, sub)
+		}
+	}
+	return found, missing, nil
+}
+
+// formatSubstrings returns the given substrings formatted one per line.
+func formatSubstrings(subs []string) string {
+	var buf bytes.Buffer
+	for _, sub := range subs {
+		fmt.Fprintf(&buf, "\n\t%q", sub)
+	}
+	return buf.String()
+}
+
+// StringsTo returns a Checker checking that the provided value implements
+// fmt.Stringer and that its String method returns the given string.
+// For instance:
+//
+//     c.Assert(time.March, qt.StringsTo("March"))
+//
+// On failure, the type and the value are reported together with the produced
+// string. Use StringsToMatch to check the string against a regular expression.
+func StringsTo(want string) Checker {
+	return &stringsToChecker{
+		want: want,
+	}
+}
+
+// StringsToMatch returns a Checker checking that the provided value implements
+// fmt.Stringer and that its String method returns a string matching the given
+// regular expression pattern. As with Matches, the pattern must match the
+// whole string.
+// For instance:
+//
+//     c.Assert(id, qt.StringsToMatch(`[0-9a-f]{8}`))
+//
+func StringsToMatch(pattern string) Checker {
+	return &stringsToChecker{
+		want:  pattern,
+		match: true,
+	}
+}
+
+type stringsToChecker struct {
+	numArgs
+	want  string
+	match bool
+}
+
+// Check implements Checker.Check by checking that got is a fmt.Stringer
+// producing the expected string.
+func (c *stringsToChecker) Check(got interface{}, args []interface{}) error {
+	s, ok, err := c.check(got)
+	if err != nil || ok {
+		return err
+	}
+	if c.match {
+		return fmt.Errorf(
+			"string representation does not match the pattern:\n(type)\n\t%T\n(value)\n\t%#v\n(string)\n\t%q\n(pattern)\n\t%q",
+			got, got, s, c.want)
+	}
+	return fmt.Errorf(
+		"string representation is not equal to the expected string:\n(type)\n\t%T\n(value)\n\t%#v\n%s\t-: %q\n\t+: %q",
+		got, got, notEqualErrorPrefix, s, c.want)
+}
+
+// Negate implements Checker.Negate by checking that got is a fmt.Stringer
+// not producing the expected string.
+func (c *stringsToChecker) Negate(got interface{}, args []interface{}) error {
+	s, ok, err := c.check(got)
+	if err != nil || !ok {
+		return err
+	}
+	if c.match {
+		return fmt.Errorf(
+			"string representation matches the pattern, but should not:\n(type)\n\t%T\n(string)\n\t%q\n(pattern)\n\t%q",
+			got, s, c.want)
+	}
+	return fmt.Errorf(
+		"string representation is equal to the expected string, but should not:\n(type)\n\t%T\n(string)\n\t%q",
+		got, s)
+}
+
+// check returns the string representation of got, and whether it is the
+// expected one.
+func (c *stringsToChecker) check(got interface{}) (s string, ok bool, err error) {
+	v, ok := got.(fmt.Stringer)
+	if !ok {
+		return "", false, BadCheckf("expected a fmt.Stringer, got %T instead", got)
+	}
+	s = v.String()
+	if !c.match {
+		return s, s == c.want, nil
+	}
+	err = match(s, c.want, "")
+	if IsBadCheck(err) {
+		return "", false, err
+	}
+	return s, err == nil, nil
+}
+
+// MatchesCaptures returns a Checker checking that the provided string matches
+// the given regular expression pattern, and that the text captured by its
+// named groups is equal to the expected values, provided as a
+// map[string]string from group names to captured text. Only the groups
+// included in the map are checked. As with Matches, the pattern must match the
+// whole string.
+// For instance:
+//
+//     c.Assert(line, qt.MatchesCaptures(`level=(?P<level>\w+) msg="(?P<msg>.*)".*`), map[string]string{
+//         "level": "error",
+//         "msg":   "bad wolf",
+//     })
+//
+func MatchesCaptures(pattern string) Checker {
+	return &matchesCapturesChecker{
+		numArgs: 1,
+		pattern: pattern,
+	}
+}
+
+type matchesCapturesChecker struct {
+	numArgs
+	pattern string
+}
+
+// Check implements Checker.Check by checking that got matches the stored
+// pattern and that its named captures are equal to the ones in args[0].
+func (c *matchesCapturesChecker) Check(got interface{}, args []interface{}) error {
+	s, ok := got.(string)
+	if !ok {
+		return BadCheckf("expected a string, got %T instead", got)
+	}
+	want, ok := args[0].(map[string]string)
+	if !ok {
+		return BadCheckf("expected captures must be a map[string]string, got %T instead", args[0])
+	}
+	regex, err := regexp.Compile("^(" + c.pattern + ")$")
+	if err != nil {
+		return BadCheckf("cannot compile regular expression %q: %s", c.pattern, err)
+	}
+	names := make(map[string]int)
+	for i, name := range regex.SubexpNames() {
+		if name != "" {
+			names[name] = i
+		}
+	}
+	for name := range want {
+		if _, ok := names[name]; !ok {
+			return BadCheckf("regular expression %q has no group named %q", c.pattern, name)
+		}
+	}
+	submatches := regex.FindStringSubmatch(s)
+	if submatches == nil {
+		return &mismatchError{
+			msg:     "string mismatch",
+			got:     s,
+			pattern: c.pattern,
+		}
+	}
+	captures := make(map[string]string, len(want))
+	equal := true
+	for name, value := range want {
+		captures[name] = submatches[names[name]]
+		equal = equal && captures[name] == value
+	}
+	if equal {
+		return nil
+	}
+	return fmt.Errorf("captures are not equal:\n(value)\n\t%q\n(pattern)\n\t%q\n%s\t-: %#v\n\t+: %#v", s, c.pattern, notEqualErrorPrefix, captures, want)
+}
+
+// Negate implements Checker.Negate by checking that got does not match the
+// stored pattern, or that its named captures are not equal to the ones in
+// args[0].
+func (c *matchesCapturesChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "%q matches %q with the expected captures:\n(captures)\n\t%#v", got, c.pattern, args[0])
+}
+
+// ErrorMatches is a Checker checking that the provided value is an error whose
+// message matches the provided regular expression pattern.
+// For instance:
+//
+//     c.Assert(err, qt.ErrorMatches, "bad wolf .*")
+//
+var ErrorMatches Checker = &errorMatchesChecker{
+	numArgs: 1,
+}
+
+type errorMatchesChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is an error whose
+// Error() matches args[0].
+func (c *errorMatchesChecker) Check(got interface{}, args []interface{}) error {
+	pattern := args[0]
+	err, ok := got.(error)
+	if !ok {
+		return BadCheckf("did not get an error, got %T instead", got)
+	}
+	if err == nil {
+		return fmt.Errorf("error is nil, therefore it does not match %q", pattern)
+	}
+	return match(err.Error(), pattern, "error message mismatch")
+}
+
+// Negate implements Checker.Negate by checking that got is either nil or
+// an error whose String() does not match args[0].
+func (c *errorMatchesChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "error %q matches %q", got, args[0])
+}
+
+// ErrorMatchesChain is a Checker checking that the provided value is an error
+// which has, or wraps, an error whose message matches the provided regular
+// expression pattern. Wrapped errors are retrieved using their Unwrap method,
+// so the check succeeds if the message of any error in the chain matches.
+// For instance:
+//
+//     c.Assert(err, qt.ErrorMatchesChain, "permission denied")
+//
+// On failure, the messages of all the errors in the chain are reported.
+var ErrorMatchesChain Checker = &errorMatchesChainChecker{
+	numArgs: 1,
+}
+
+type errorMatchesChainChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is an error whose
+// chain includes an error whose Error() matches args[0].
+func (c *errorMatchesChainChecker) Check(got interface{}, args []interface{}) error {
+	_, _, err := c.matching(got, args[0])
+	return err
+}
+
+// Negate implements Checker.Negate by checking that got is an error whose
+// chain does not include an error whose Error() matches args[0]. As with
+// Check, a nil got value is reported as a bad check.
+func (c *errorMatchesChainChecker) Negate(got interface{}, args []interface{}) error {
+	matching, depth, err := c.matching(got, args[0])
+	if IsBadCheck(err) {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	if depth == 0 {
+		return fmt.Errorf("error %q matches %q, but should not", matching, args[0])
+	}
+	return fmt.Errorf("error %q wrapped by %q matches %q, but should not", matching, got, args[0])
+}
+
+// matching returns the first error in the chain of got whose message matches
+// the given pattern, and its depth in the chain, zero being got itself.
+func (c *errorMatchesChainChecker) matching(got, pattern interface{}) (matching error, depth int, err error) {
+	gotErr, ok := got.(error)
+	if !ok {
+		return nil, 0, BadCheckf("did not get an error, got %T instead", got)
+	}
+	var buf bytes.Buffer
+	for e := gotErr; e != nil; e = unwrap(e) {
+		mismatch := match(e.Error(), pattern, "")
+		if mismatch == nil {
+			return e, depth, nil
+		}
+		if IsBadCheck(mismatch) {
+			return nil, 0, mismatch
+		}
+		fmt.Fprintf(&buf, "\n\t%q", e.Error())
+		depth++
+	}
+	return nil, 0, fmt.Errorf("no error in the chain matches the pattern:\n(pattern)\n\t%q\n(errors)%s", pattern, buf.String())
+}
+
+// WrapsWithMessage returns a Checker checking that the provided error wraps
+// the given cause, and that its message matches the given regular expression
+// pattern. The cause is found if it is equal to the provided error or to any
+// error in its chain, as retrieved using Unwrap methods, or if any of those
+// errors has an Is method reporting it as equivalent to the cause. This
+// captures the intent of errors built like fmt.Errorf("doing x: %w", cause).
+// For instance:
+//
+//     c.Assert(err, qt.WrapsWithMessage(os.ErrNotExist, "cannot load config: .*"))
+//
+func WrapsWithMessage(cause error, pattern string) Checker {
+	return &wrapsWithMessageChecker{
+		cause:   cause,
+		pattern: pattern,
+	}
+}
+
+type wrapsWithMessageChecker struct {
+	numArgs
+	cause   error
+	pattern string
+}
+
+// Check implements Checker.Check by checking that got is an error wrapping
+// the stored cause whose message matches the stored pattern.
+func (c *wrapsWithMessageChecker) Check(got interface{}, args []interface{}) error {
+	wraps, matches, err := c.results(got)
+	if err != nil {
+		return err
+	}
+	if wraps && matches {
+		return nil
+	}
+	msg := "error does not wrap the expected cause"
+	if wraps {
+		msg = "error message does not match the pattern"
+	} else if !matches {
+		msg += ", and its message does not match the pattern"
+	}
+	return fmt.Errorf("%s:\n(error)\n\t%q\n(cause)\n\t%q\n(pattern)\n\t%q\n(cause wrapped)\n\t%v\n(message matches)\n\t%v", msg, got, c.cause, c.pattern, wraps, matches)
+}
+
+// Negate implements Checker.Negate by checking that got is an error that
+// does not wrap the stored cause, or whose message does not match the stored
+// pattern.
+func (c *wrapsWithMessageChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "error wraps the cause and its message matches the pattern:\n(error)\n\t%q\n(cause)\n\t%q\n(pattern)\n\t%q", got, c.cause, c.pattern)
+}
+
+// results reports whether got wraps the stored cause and whether its message
+// matches the stored pattern.
+func (c *wrapsWithMessageChecker) results(got interface{}) (wraps, matches bool, err error) {
+	if c.cause == nil {
+		return false, false, BadCheckf("cause must not be nil")
+	}
+	gotErr, ok := got.(error)
+	if !ok {
+		return false, false, BadCheckf("did not get an error, got %T instead", got)
+	}
+	err = match(gotErr.Error(), c.pattern, "")
+	if IsBadCheck(err) {
+		return false, false, err
+	}
+	return isError(gotErr, c.cause), err == nil, nil
+}
+
+// isError reports whether any error in the chain of err is equal to target,
+// or has an Is method reporting it as equivalent to target.
+func isError(err, target error) bool {
+	return matchError(err, target) != nil
+}
+
+// matchError returns the first error in the chain of err that is equal to
+// target, or has an Is method reporting it as equivalent to target. It returns
+// nil if there is no such error.
+func matchError(err, target error) error {
+	comparable := reflect.TypeOf(target).Comparable()
+	for e := err; e != nil; e = unwrap(e) {
+		if comparable && reflect.TypeOf(e).Comparable() && e == target {
+			return e
+		}
+		if x, ok := e.(interface {
+			Is(error) bool
+		}); ok && x.Is(target) {
+			return e
+		}
+	}
+	return nil
+}
+
+// ErrorIs is a Checker checking that the provided error is, or wraps, the
+// provided target error, in the same way as errors.Is: an error in the chain
+// matches if it is equal to the target, or if it has an Is method reporting
+// it as equivalent.
+// For instance:
+//
+//     c.Assert(err, qt.ErrorIs, os.ErrNotExist)
+//     c.Assert(err, qt.Not(qt.ErrorIs), context.Canceled)
+//
+// When negated, the error in the chain matching the target is reported.
+var ErrorIs Checker = &errorIsChecker{
+	numArgs: 1,
+}
+
+type errorIsChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is, or wraps, args[0].
+func (c *errorIsChecker) Check(got interface{}, args []interface{}) error {
+	err, target, matched, checkErr := c.match(got, args[0])
+	if checkErr != nil {
+		return checkErr
+	}
+	if err == nil {
+		return fmt.Errorf("error is nil, therefore it is not the target:\n(target)\n\t%q", target)
+	}
+	if matched == nil {
+		return fmt.Errorf("error is not the target:\n(error)\n\t%q\n(target)\n\t%q", err, target)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is nil, or neither is
+// nor wraps args[0].
+func (c *errorIsChecker) Negate(got interface{}, args []interface{}) error {
+	err, target, matched, checkErr := c.match(got, args[0])
+	if checkErr != nil {
+		return checkErr
+	}
+	if matched == nil {
+		return nil
+	}
+	return fmt.Errorf("error %q is target %q, but should not be:\n(error)\n\t%q\n(matching error)\n\t%q\n(target)\n\t%q", err, target, err, matched, target)
+}
+
+// match returns got and the target as errors, and the first error in the
+// chain of got matching the target, or nil if no errors match.
+func (c *errorIsChecker) match(got, want interface{}) (err, target, matched error, checkErr error) {
+	target, ok := want.(error)
+	if !ok {
+		return nil, nil, nil, BadCheckf("target must be a non-nil error, got %T instead", want)
+	}
+	if got == nil {
+		return nil, target, nil, nil
+	}
+	err, ok = got.(error)
+	if !ok {
+		return nil, nil, nil, BadCheckf("did not get an error, got %T instead", got)
+	}
+	return err, target, matchError(err, target), nil
+}
+
+// ErrorOfType is a Checker checking that the provided value is an error whose
+// dynamic type is the type of the provided value, usually a typed nil pointer.
+// Errors wrapped using an Unwrap method are also checked, so the check
+// succeeds if any error in the chain has the expected type.
+// For instance:
+//
+//     c.Assert(err, qt.ErrorOfType, (*os.PathError)(nil))
+//
+var ErrorOfType Checker = &errorOfTypeChecker{
+	numArgs: 1,
+}
+
+type errorOfTypeChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is an error, or wraps an
+// error, with the same type as args[0].
+func (c *errorOfTypeChecker) Check(got interface{}, args []interface{}) error {
+	want := reflect.TypeOf(args[0])
+	if want == nil {
+		return BadCheckf("expected error type must be provided as a typed value, got nil")
+	}
+	if got == nil {
+		return fmt.Errorf("error is nil, therefore it is not of type %s", want)
+	}
+	err, ok := got.(error)
+	if !ok {
+		return BadCheckf("did not get an error, got %T instead", got)
+	}
+	for e := err; e != nil; e = unwrap(e) {
+		if reflect.TypeOf(e) == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("error type mismatch:\n(-got +want)\n\t-: %T\n\t+: %s\n(error)\n\t%s", err, want, err)
+}
+
+// Negate implements Checker.Negate by checking that got is either nil or an
+// error that does not have, nor wrap, an error with the same type as args[0].
+func (c *errorOfTypeChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "error is of type %s:\n(error)\n\t%s", reflect.TypeOf(args[0]), got)
+}
+
+// unwrap returns the error wrapped by err, or nil if err does not wrap any
+// error.
+func unwrap(err error) error {
+	u, ok := err.(interface {
+		Unwrap() error
+	})
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
+// IsType returns a Checker checking that the dynamic type of the provided
+// value is exactly the given type. The type is provided as a typed nil pointer
+// to a value of that type, so that interface types can also be specified.
+// This is useful for instance when decoding into an interface{} value.
+// For instance:
+//
+//     c.Assert(decoded["answer"], qt.IsType((*float64)(nil)))
+//
+// Use IsAssignableTo to check that the value can be assigned to a variable of
+// the given type, for instance an interface type.
+func IsType(typ interface{}) Checker {
+	return &isTypeChecker{
+		typ: typ,
+	}
+}
+
+// IsAssignableTo returns a Checker checking that the dynamic type of the
+// provided value is assignable to the given type, which is provided as a
+// typed nil pointer as with IsType.
+// For instance:
+//
+//     c.Assert(v, qt.IsAssignableTo((*io.Reader)(nil)))
+//
+func IsAssignableTo(typ interface{}) Checker {
+	return &isTypeChecker{
+		typ:        typ,
+		assignable: true,
+	}
+}
+
+type isTypeChecker struct {
+	numArgs
+	typ        interface{}
+	assignable bool
+}
+
+// Check implements Checker.Check by checking that got has the stored type, or
+// is assignable to it.
+func (c *isTypeChecker) Check(got interface{}, args []interface{}) error {
+	want, ok, err := c.check(got)
+	if err != nil || ok {
+		return err
+	}
+	if got == nil {
+		return fmt.Errorf("value is nil, therefore it has no dynamic type:\n(want)\n\t%s", want)
+	}
+	if c.assignable {
+		return fmt.Errorf("value of type %T is not assignable to %s:\n(value)\n\t%#v", got, want, got)
+	}
+	return fmt.Errorf("type mismatch:\n(-got +want)\n\t-: %T\n\t+: %s\n(value)\n\t%#v", got, want, got)
+}
+
+// Negate implements Checker.Negate by checking that got does not have the
+// stored type, or is not assignable to it.
+func (c *isTypeChecker) Negate(got interface{}, args []interface{}) error {
+	want, ok, err := c.check(got)
+	if err != nil || !ok {
+		return err
+	}
+	if c.assignable {
+		return fmt.Errorf("value of type %T is assignable to %s, but should not:\n(value)\n\t%#v", got, want, got)
+	}
+	return fmt.Errorf("value is of type %s, but should not:\n(value)\n\t%#v", want, got)
+}
+
+// check returns the stored type, and whether got has that type or is
+// assignable to it.
+func (c *isTypeChecker) check(got interface{}) (want reflect.Type, ok bool, err error) {
+	t := reflect.TypeOf(c.typ)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, false, BadCheckf("type must be provided as a typed nil pointer, like (*int)(nil), got %T instead", c.typ)
+	}
+	want = t.Elem()
+	gotType := reflect.TypeOf(got)
+	if gotType == nil {
+		return want, false, nil
+	}
+	if c.assignable {
+		return want, gotType.AssignableTo(want), nil
+	}
+	return want, gotType == want, nil
+}
+
+// PanicMatches is a Checker checking that the provided function panics with a
+// message matching the provided regular expression pattern.
+// For instance:
+//
+//     c.Assert(func() {panic("bad wolf ...")}, qt.PanicMatches, "bad wolf .*")
+//
+// Use PanicMatchesTimeout for functions that could block forever.
+var PanicMatches Checker = &panicMatchesChecker{
+	numArgs: 1,
+}
+
+// PanicMatchesTimeout returns a Checker which is like PanicMatches, but which
+// fails if the provided function does not return or panic within the given
+// timeout, instead of blocking the test forever, for instance when the
+// function deadlocks.
+// For instance:
+//
+//     c.Assert(func() { q.Pop() }, qt.PanicMatchesTimeout(time.Second), "empty queue")
+//
+// Note that the function is executed in its own goroutine, and it keeps
+// running in the background if it does not return within the timeout.
+func PanicMatchesTimeout(timeout time.Duration) Checker {
+	return &panicMatchesChecker{
+		numArgs: 1,
+		timeout: timeout,
+	}
+}
+
+type panicMatchesChecker struct {
+	numArgs
+	timeout time.Duration
+}
+
+// Check implements Checker.Check by checking that got is a func() that panics
+// with a message matching args[0].
+func (c *panicMatchesChecker) Check(got interface{}, args []interface{}) error {
+	f := reflect.ValueOf(got)
+	if f.Kind() != reflect.Func {
+		return BadCheckf("expected a function, got %T instead", got)
+	}
+	ftype := f.Type()
+	if ftype.NumIn() != 0 {
+		return BadCheckf(
+			"expected a function accepting no arguments, got %T instead", got)
+	}
+	r, err := c.call(f)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("the function did not panic")
+	}
+	var msg string
+	if panicErr, ok := r.(error); ok {
+		msg = panicErr.Error()
+	} else {
+		msg = fmt.Sprintf("%s", r)
+	}
+	pattern := args[0]
+	return match(msg, pattern, "panic message mismatch")
+}
+
+// Negate implements Checker.Negate by checking that got is a func() that does
+// not panic with the given message.
+func (c *panicMatchesChecker) Negate(got interface{}, args []interface{}) error {
+	err := c.Check(got, args)
+	if IsBadCheck(err) {
+		return err
+	}
+	if _, ok := err.(*deadlineError); ok {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	pattern := args[0]
+	return fmt.Errorf("there was a panic matching %q", pattern)
+}
+
+// call calls the given function and returns the value it panicked with, or
+// nil if it returned normally. If a timeout is set, a *deadlineError is
+// returned if the function does not complete in time.
+func (c *panicMatchesChecker) call(f reflect.Value) (r interface{}, err error) {
+	if c.timeout == 0 {
+		defer func() {
+			r = recover()
+		}()
+		f.Call(nil)
+		return nil, nil
+	}
+	done := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+		f.Call(nil)
+	}()
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r, nil
+	case <-timer.C:
+		return nil, &deadlineError{
+			timeout: c.timeout,
+		}
+	}
+}
+
+// deadlineError is the error returned when a function does not complete
+// within the allowed time. It is reported regardless of whether the check is
+// negated.
+type deadlineError struct {
+	timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e *deadlineError) Error() string {
+	return fmt.Sprintf("the function did not return (possible deadlock) within %v", e.timeout)
+}
+
+// DoesNotPanic is a Checker checking that the provided function does not
+// panic when called. On failure, the recovered value and the stack of the
+// panicking goroutine are reported.
+// For instance:
+//
+//     c.Assert(func() { parse(input) }, qt.DoesNotPanic)
+//
+var DoesNotPanic Checker = &doesNotPanicChecker{}
+
+type doesNotPanicChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is a func() that does
+// not panic.
+func (c *doesNotPanicChecker) Check(got interface{}, args []interface{}) (err error) {
+	f := reflect.ValueOf(got)
+	if f.Kind() != reflect.Func {
+		return BadCheckf("expected a function, got %T instead", got)
+	}
+	if f.Type().NumIn() != 0 {
+		return BadCheckf("expected a function accepting no arguments, got %T instead", got)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("the function panicked:\n(panic)\n\t%v\n(stack)\n%s", r, panicStack())
+		}
+	}()
+
+	f.Call(nil)
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is a func() that
+// panics.
+func (c *doesNotPanicChecker) Negate(got interface{}, args []interface{}) error {
+	err := c.Check(got, args)
+	if IsBadCheck(err) {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	return errors.New("the function did not panic")
+}
+
+// maxPanicStackFrames holds the maximum number of stack frames reported when
+// a function unexpectedly panics.
+const maxPanicStackFrames = 10
+
+// panicStack returns the stack of the panicking function, formatted for
+// inclusion in failure reports. It must be called by a deferred function
+// recovering from the panic. Runtime frames are omitted, and the stack stops
+// at the call performed by the checker.
+func panicStack() string {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	lines := make([]string, 0, maxPanicStackFrames)
+	for len(lines) < maxPanicStackFrames {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, "reflect.") {
+			break
+		}
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			lines = append(lines, fmt.Sprintf("\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// IsValidUTF8 is a Checker checking that the provided string or []byte is
+// valid UTF-8 encoded text.
+// For instance:
+//
+//     c.Assert(output, qt.IsValidUTF8)
+//
+var IsValidUTF8 Checker = &isValidUTF8Checker{}
+
+type isValidUTF8Checker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is valid UTF-8.
+func (c *isValidUTF8Checker) Check(got interface{}, args []interface{}) error {
+	var data []byte
+	switch v := got.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return BadCheckf("expected a string or a []byte, got %T instead", got)
+	}
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		if r == utf8.RuneError && size == 1 {
+			return fmt.Errorf("invalid UTF-8 sequence at byte offset %d:\n(value)\n\t%q", offset, got)
+		}
+		offset += size
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is not valid UTF-8.
+func (c *isValidUTF8Checker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value is valid UTF-8:\n(value)\n\t%q", got)
+}
+
+// IsValidJSON is a Checker checking that the provided string or []byte is a
+// valid JSON document, without comparing it with any expected value.
+// For instance:
+//
+//     c.Assert(rec.Body.Bytes(), qt.IsValidJSON)
+//
+// On failure, the parse error and its byte offset are reported.
+var IsValidJSON Checker = &isValidJSONChecker{}
+
+type isValidJSONChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is valid JSON.
+func (c *isValidJSONChecker) Check(got interface{}, args []interface{}) error {
+	data, ok := documentBytes(got)
+	if !ok {
+		return BadCheckf("expected a JSON string or []byte, got %T instead", got)
+	}
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+	if err == nil {
+		return nil
+	}
+	if serr, ok := err.(*json.SyntaxError); ok {
+		return fmt.Errorf("invalid JSON at byte offset %d: %s\n(value)\n\t%q", serr.Offset, serr, got)
+	}
+	return fmt.Errorf("invalid JSON: %s\n(value)\n\t%q", err, got)
+}
+
+// Negate implements Checker.Negate by checking that got is not valid JSON.
+func (c *isValidJSONChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value is valid JSON:\n(value)\n\t%q", got)
+}
+
+// MarshalsToJSON returns a Checker checking that the provided value, when
+// marshaled with encoding/json, produces a JSON document semantically equal to
+// the given one, so that whitespace and the ordering of object keys are not
+// relevant. This is useful for testing the JSON representation of a type,
+// including its field names and tags, rather than whether it round trips.
+// For instance:
+//
+//     c.Assert(user, qt.MarshalsToJSON(`{"id": 42, "name": "bad wolf"}`))
+//
+// On failure, the produced JSON and a diff against the expected document are
+// reported.
+func MarshalsToJSON(want string) Checker {
+	return &marshalsToJSONChecker{
+		want: want,
+	}
+}
+
+type marshalsToJSONChecker struct {
+	numArgs
+	want string
+}
+
+// Check implements Checker.Check by checking that got marshals to JSON
+// equivalent to the stored document.
+func (c *marshalsToJSONChecker) Check(got interface{}, args []interface{}) error {
+	data, gotJSON, wantJSON, err := c.marshal(got)
+	if err != nil {
+		return err
+	}
+	if diff := unifiedDiff("got", gotJSON, "want", wantJSON); diff != "" {
+		return fmt.Errorf("value does not marshal to the expected JSON:\n(json)\n\t%s\n(diff)\n%s", data, indent(strings.TrimSuffix(diff, "\n"), "\t"))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got does not marshal to
+// JSON equivalent to the stored document.
+func (c *marshalsToJSONChecker) Negate(got interface{}, args []interface{}) error {
+	data, _ := json.Marshal(got)
+	return NegateCheckf(c.Check(got, args), "value marshals to the expected JSON:\n(json)\n\t%s", data)
+}
+
+// marshal marshals the given value to JSON, and returns the resulting data
+// along with the canonical forms of both the resulting and expected JSON.
+func (c *marshalsToJSONChecker) marshal(got interface{}) (data []byte, gotJSON, wantJSON string, err error) {
+	data, err = json.Marshal(got)
+	if err != nil {
+		return nil, "", "", BadCheckf("cannot marshal value to JSON: %s", err)
+	}
+	if gotJSON, err = canonicalJSON(data); err != nil {
+		return nil, "", "", BadCheckf("cannot parse marshaled JSON: %s", err)
+	}
+	if wantJSON, err = canonicalJSON([]byte(c.want)); err != nil {
+		return nil, "", "", BadCheckf("cannot parse expected JSON: %s", err)
+	}
+	return data, gotJSON, wantJSON, nil
+}
+
+// canonicalJSON returns the canonical form of the given JSON document,
+// indented and with sorted object keys, so that it can be compared and
+// diffed line by line.
+func canonicalJSON(data []byte) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
+
+// documentBytes returns the given document as a []byte. The ok return value
+// is false if v is neither a string nor a []byte.
+func documentBytes(v interface{}) (data []byte, ok bool) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	}
+	return nil, false
+}
+
+// CompletesWithin returns a Checker checking that the provided function,
+// which must accept no arguments, returns within the given duration.
+// For instance:
+//
+//     c.Assert(func() { server.Shutdown() }, qt.CompletesWithin(time.Second))
+//
+// Note that the function is executed in its own goroutine, and it keeps
+// running in the background if it does not return within the deadline. A
+// panicking function is reported as a failure, even when the checker is
+// negated.
+func CompletesWithin(d time.Duration) Checker {
+	return &completesWithinChecker{
+		deadline: d,
+	}
+}
+
+type completesWithinChecker struct {
+	numArgs
+	deadline time.Duration
+}
+
+// Check implements Checker.Check by checking that got is a func() returning
+// within the stored deadline.
+func (c *completesWithinChecker) Check(got interface{}, args []interface{}) error {
+	_, err := c.run(got)
+	return err
+}
+
+// Negate implements Checker.Negate by checking that got is a func() not
+// returning within the stored deadline. A function panicking within the
+// deadline is reported as a failure.
+func (c *completesWithinChecker) Negate(got interface{}, args []interface{}) error {
+	elapsed, err := c.run(got)
+	if _, ok := err.(*notCompletedError); ok {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("the function returned within the deadline, but should not:\n(deadline)\n\t%v\n(elapsed)\n\t%v", c.deadline, elapsed)
+}
+
+// run calls the given function and waits for it to return, for at most the
+// stored deadline. It returns the time the function took to return.
+func (c *completesWithinChecker) run(got interface{}) (time.Duration, error) {
+	f := reflect.ValueOf(got)
+	if f.Kind() != reflect.Func {
+		return 0, BadCheckf("expected a function, got %T instead", got)
+	}
+	if f.Type().NumIn() != 0 {
+		return 0, BadCheckf("expected a function accepting no arguments, got %T instead", got)
+	}
+	done := make(chan interface{}, 1)
+	start := time.Now()
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+		f.Call(nil)
+	}()
+	timer := time.NewTimer(c.deadline)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		elapsed := time.Since(start)
+		if r != nil {
+			return elapsed, fmt.Errorf("the function panicked: %v", r)
+		}
+		return elapsed, nil
+	case <-timer.C:
+		elapsed := time.Since(start)
+		return elapsed, &notCompletedError{
+			deadline: c.deadline,
+			elapsed:  elapsed,
+		}
+	}
+}
+
+// notCompletedError is the error returned by completesWithinChecker.run when
+// the function does not return within the deadline, so that it can be told
+// apart from the function panicking.
+type notCompletedError struct {
+	deadline time.Duration
+	elapsed  time.Duration
+}
+
+// Error implements the error interface.
+func (e *notCompletedError) Error() string {
+	return fmt.Sprintf("the function did not return within the deadline:\n(deadline)\n\t%v\n(elapsed)\n\t%v", e.deadline, e.elapsed)
+}
+
+// ContextDone returns a Checker checking that the provided context.Context is
+// done, and that its error is, or wraps, the given error, as reported by its
+// Err method. If the given error is nil, any error is accepted. The check
+// does not wait for the context to be done.
+// For instance:
+//
+//     cancel()
+//     c.Assert(ctx, qt.ContextDone(context.Canceled))
+//
+func ContextDone(wantErr error) Checker {
+	return &contextDoneChecker{
+		wantErr: wantErr,
+	}
+}
+
+type contextDoneChecker struct {
+	numArgs
+	wantErr error
+}
+
+// Check implements Checker.Check by checking that got is a done context
+// whose error matches the stored one.
+func (c *contextDoneChecker) Check(got interface{}, args []interface{}) error {
+	ctx, ok := got.(context.Context)
+	if !ok {
+		return BadCheckf("expected a context.Context, got %T instead", got)
+	}
+	select {
+	case <-ctx.Done():
+	default:
+		return fmt.Errorf("context not done:\n(error)\n\t%v", ctx.Err())
+	}
+	err := ctx.Err()
+	if c.wantErr == nil || isError(err, c.wantErr) {
+		return nil
+	}
+	return fmt.Errorf("context done with unexpected error:\n(error)\n\t%q\n(want)\n\t%q", err, c.wantErr)
+}
+
+// Negate implements Checker.Negate by checking that got is a context which
+// is not done, or whose error does not match the stored one.
+func (c *contextDoneChecker) Negate(got interface{}, args []interface{}) error {
+	var ctxErr error
+	if ctx, ok := got.(context.Context); ok {
+		ctxErr = ctx.Err()
+	}
+	return NegateCheckf(c.Check(got, args), "context is done:\n(error)\n\t%q", ctxErr)
+}
+
+// IsClosed is a Checker checking that the provided channel is closed. The
+// check is performed with a non-blocking receive, so it never blocks. If the
+// channel is open and no value is ready to be received, the check fails
+// reporting that the channel is open and empty. If the channel is unbuffered
+// and a sender is ready, the sent value is received, and therefore consumed,
+// and reported as pending. If values are pending in the buffer of a buffered
+// channel, whether the channel is closed cannot be determined without
+// consuming them: in that case nothing is received and the check fails as a
+// bad check, even when negated.
+// Receive-only and bidirectional channels are supported.
+// For instance:
+//
+//     c.Assert(done, qt.IsClosed)
+//     c.Assert(results, qt.Not(qt.IsClosed))
+//
+var IsClosed Checker = &isClosedChecker{}
+
+type isClosedChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is a closed channel.
+func (c *isClosedChecker) Check(got interface{}, args []interface{}) error {
+	v := reflect.ValueOf(got)
+	if v.Kind() != reflect.Chan {
+		return BadCheckf("expected a channel, got %T instead", got)
+	}
+	if v.Type().ChanDir()&reflect.RecvDir == 0 {
+		return BadCheckf("cannot receive from send-only channel of type %T", got)
+	}
+	if v.IsNil() {
+		return errors.New("channel is not closed:\n(state)\n\tnil")
+	}
+	if n := v.Len(); n > 0 {
+		return BadCheckf("cannot determine whether the channel is closed: %d buffered value(s) pending", n)
+	}
+	x, ok := v.TryRecv()
+	switch {
+	case ok:
+		return fmt.Errorf("channel is not closed:\n(state)\n\topen with a pending value\n(received value)\n\t%#v", x.Interface())
+	case !x.IsValid():
+		return errors.New("channel is not closed:\n(state)\n\topen and empty")
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is a channel that is
+// not closed.
+func (c *isClosedChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "channel is closed")
+}
+
+// IsNil is a Checker checking that the provided value is nil.
+// For instance:
+//
+//     c.Assert(got, qt.IsNil)
+//
+// When the provided value is a non-nil error, the failure output includes the
+// error message and, if different, its verbose "%+v" representation, which
+// for some error implementations includes wrapping and stack information.
+var IsNil Checker = &isNilChecker{}
+
+type isNilChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is nil.
+func (c *isNilChecker) Check(got interface{}, args []interface{}) (err error) {
+	if got == nil {
+		return nil
+	}
+	value := reflect.ValueOf(got)
+	switch value.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		if value.IsNil() {
+			return nil
+		}
+	}
+	if e, ok := got.(error); ok {
+		msg := "error is not nil:\n(error)\n" + indent(e.Error(), "\t")
+		if verbose := fmt.Sprintf("%+v", e); verbose != e.Error() {
+			msg += "\n(verbose)\n" + indent(verbose, "\t")
+		}
+		return errors.New(msg)
+	}
+	return fmt.Errorf("%#v is not nil", got)
+}
+
+// Negate implements Checker.Negate by checking that got is not nil.
+func (c *isNilChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the value is nil")
+}
+
+// IsZero is a Checker checking that the provided value is the zero value for
+// its type, for instance 0 for numbers, "" for strings, nil for pointers and a
+// struct whose fields are all zero for structs. A nil interface value is also
+// considered zero.
+// For instance:
+//
+//     c.Assert(user.DeletedAt, qt.IsZero)
+//
+// Note that, unlike with IsNil, empty but non-nil slices and maps are not
+// zero values.
+var IsZero Checker = &isZeroChecker{}
+
+type isZeroChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is the zero value for
+// its type.
+func (c *isZeroChecker) Check(got interface{}, args []interface{}) error {
+	if got == nil {
+		return nil
+	}
+	value := reflect.ValueOf(got)
+	if isZero(value) {
+		return nil
+	}
+	return fmt.Errorf("value is not the zero value for its type:\n(value)\n\t%#v\n(zero value)\n\t%#v", got, reflect.Zero(value.Type()).Interface())
+}
+
+// Negate implements Checker.Negate by checking that got is not the zero
+// value for its type.
+func (c *isZeroChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "value is the zero value for its type:\n(value)\n\t%#v\n(type)\n\t%T", got, got)
+}
+
+// isZero reports whether v is the zero value for its type. It behaves like
+// reflect.Value.IsZero, which is only available from Go 1.13: in particular,
+// negative zero floats are not zero values.
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return math.Float64bits(v.Float()) == 0
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return math.Float64bits(real(c)) == 0 && math.Float64bits(imag(c)) == 0
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isZero(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return v.IsNil()
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isZero(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	panic(fmt.Sprintf("unexpected kind %s", v.Kind()))
+}
+
+// HasLen is a Checker checking that the provided value has the provided length.
+// For instance:
+//
+//     c.Assert([]int{42, 47}, qt.HasLen, 2)
+//     c.Assert(myMap, qt.HasLen, 42)
+//
+var HasLen Checker = &hasLenChecker{
+	numArgs: 1,
+}
+
+type hasLenChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that len(got) == args[0].
+func (c *hasLenChecker) Check(got interface{}, args []interface{}) (err error) {
+	want, ok := args[0].(int)
+	if !ok {
+		return BadCheckf("expected length is of type %T, not int", args[0])
+	}
+	length, err := valueLen(got)
+	if err != nil {
+		return err
+	}
+	if length != want {
+		return fmt.Errorf("the provided value has not the expected length of %d:\n(value)\n\t%#v\n(-got length +want length)\n\t-: %d\n\t+: %d", want, got, length, want)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that len(got) != args[0].
+func (c *hasLenChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value has a length of %d:\n(value)\n\t%#v", args[0], got)
+}
+
+// HasLenBetween returns a Checker checking that the provided value has a
+// length within the given range, bounds included. It works on the same types
+// as HasLen. For instance:
+//
+//     c.Assert(results, qt.HasLenBetween(1, 10))
+//
+func HasLenBetween(min, max int) Checker {
+	return &hasLenBetweenChecker{
+		min: min,
+		max: max,
+	}
+}
+
+type hasLenBetweenChecker struct {
+	numArgs
+	min, max int
+}
+
+// Check implements Checker.Check by checking that min <= len(got) <= max.
+func (c *hasLenBetweenChecker) Check(got interface{}, args []interface{}) error {
+	if c.min > c.max {
+		return BadCheckf("invalid length range: minimum %d is greater than maximum %d", c.min, c.max)
+	}
+	length, err := valueLen(got)
+	if err != nil {
+		return err
+	}
+	if length < c.min || length > c.max {
+		return fmt.Errorf("the provided value has a length of %d, not in the range [%d, %d]:\n(value)\n\t%#v", length, c.min, c.max, got)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that len(got) is outside the
+// stored range.
+func (c *hasLenBetweenChecker) Negate(got interface{}, args []interface{}) error {
+	length, _ := valueLen(got)
+	return NegateCheckf(c.Check(got, args), "the provided value has a length of %d, in the range [%d, %d]:\n(value)\n\t%#v", length, c.min, c.max, got)
+}
+
+// HasLineCount returns a Checker checking that the provided string, or byte
+// slice, has the given number of newline separated lines. A trailing newline
+// terminates the last line rather than starting a new one, so that both
+// "a\nb" and "a\nb\n" have two lines, and the empty string has none.
+// For instance:
+//
+//     c.Assert(stdout, qt.HasLineCount(3))
+//
+// Use HasLineCountRaw to also count the empty line after a trailing newline.
+// On failure, the number of lines and the lines themselves are reported.
+func HasLineCount(n int) Checker {
+	return &hasLineCountChecker{
+		n: n,
+	}
+}
+
+// HasLineCountRaw is like HasLineCount, but the provided value is split on
+// every newline, so that the empty text following a trailing newline counts
+// as a line. For instance, "a\nb\n" has three lines, and the empty string has
+// one.
+func HasLineCountRaw(n int) Checker {
+	return &hasLineCountChecker{
+		n:   n,
+		raw: true,
+	}
+}
+
+type hasLineCountChecker struct {
+	numArgs
+	n   int
+	raw bool
+}
+
+// Check implements Checker.Check by checking that got has the expected
+// number of lines.
+func (c *hasLineCountChecker) Check(got interface{}, args []interface{}) error {
+	lines, err := c.lines(got)
+	if err != nil {
+		return err
+	}
+	if len(lines) != c.n {
+		return fmt.Errorf("unexpected number of lines: got %d, want %d:\n(lines)%s", len(lines), c.n, formatLines(lines))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got does not have the
+// expected number of lines.
+func (c *hasLineCountChecker) Negate(got interface{}, args []interface{}) error {
+	lines, err := c.lines(got)
+	if err != nil {
+		return err
+	}
+	if len(lines) != c.n {
+		return nil
+	}
+	return fmt.Errorf("the provided value has the given number of lines (%d), but should not:\n(lines)%s", c.n, formatLines(lines))
+}
+
+// lines returns the lines in got.
+func (c *hasLineCountChecker) lines(got interface{}) ([]string, error) {
+	if c.n < 0 {
+		return nil, BadCheckf("invalid number of lines %d", c.n)
+	}
+	var s string
+	switch v := got.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return nil, BadCheckf("expected a string or a []byte, got %T instead", got)
+	}
+	if !c.raw {
+		s = strings.TrimSuffix(s, "\n")
+		if s == "" {
+			return nil, nil
+		}
+	}
+	return strings.Split(s, "\n"), nil
+}
+
+// formatLines returns the given lines formatted one per line, with their
+// indexes, or a placeholder if there are no lines.
+func formatLines(lines []string) string {
+	if len(lines) == 0 {
+		return "\n\t<none>"
+	}
+	var buf bytes.Buffer
+	for i, line := range lines {
+		fmt.Fprintf(&buf, "\n\t%d: %q", i+1, line)
+	}
+	return buf.String()
+}
+
+// HasKeys returns a Checker checking that the provided map has exactly the
+// given keys, in any order. Missing and extra keys are reported on failure.
+// The given keys must be assignable to the map key type.
+// For instance:
+//
+//     c.Assert(config, qt.HasKeys("name", "answer"))
+//
+// Use HasKeysSubset to only check that the given keys are present.
+func HasKeys(keys ...interface{}) Checker {
+	return &hasKeysChecker{
+		keys: keys,
+	}
+}
+
+// HasKeysSubset returns a Checker checking that the provided map has at least
+// the given keys, in any order. Missing keys are reported on failure.
+// For instance:
+//
+//     c.Assert(headers, qt.HasKeysSubset("Content-Type", "Date"))
+//
+func HasKeysSubset(keys ...interface{}) Checker {
+	return &hasKeysChecker{
+		keys:   keys,
+		subset: true,
+	}
+}
+
+type hasKeysChecker struct {
+	numArgs
+	keys   []interface{}
+	subset bool
+}
+
+// Check implements Checker.Check by checking that got is a map having the
+// stored keys, and no other keys unless checking for a subset.
+func (c *hasKeysChecker) Check(got interface{}, args []interface{}) error {
+	missing, extra, err := c.diff(got)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+	msg := "map does not have the expected keys:"
+	if c.subset {
+		msg = "map does not have all the expected keys:"
+	}
+	if len(missing) > 0 {
+		msg += "\n(missing)" + formatElements(missing)
+	}
+	if len(extra) > 0 {
+		msg += "\n(extra)" + formatElements(extra)
+	}
+	return errors.New(msg)
+}
+
+// Negate implements Checker.Negate by checking that got is a map missing
+// some of the stored keys, or having other keys unless checking for a
+// subset.
+func (c *hasKeysChecker) Negate(got interface{}, args []interface{}) error {
+	msg := "map has exactly the given keys"
+	if c.subset {
+		msg = "map has all the given keys"
+	}
+	return NegateCheckf(c.Check(got, args), "%s:\n(keys)%s", msg, formatElements(c.keys))
+}
+
+// diff returns the stored keys that are missing from the given map and, when
+// not checking for a subset, the map keys not included in the stored ones,
+// sorted by their string representation.
+func (c *hasKeysChecker) diff(got interface{}) (missing, extra []interface{}, err error) {
+	m := reflect.ValueOf(got)
+	if m.Kind() != reflect.Map {
+		return nil, nil, BadCheckf("expected a map, got %T instead", got)
+	}
+	keyType := m.Type().Key()
+	wanted := make(map[interface{}]bool, len(c.keys))
+	for _, k := range c.keys {
+		kv := reflect.ValueOf(k)
+		if !kv.IsValid() || !kv.Type().AssignableTo(keyType) {
+			return nil, nil, BadCheckf("key %#v of type %T is not assignable to the map key type %s", k, k, keyType)
+		}
+		if !kv.Type().Comparable() {
+			return nil, nil, BadCheckf("key %#v of type %T is not comparable", k, k)
+		}
+		if wanted[k] {
+			continue
+		}
+		wanted[k] = true
+		if !m.MapIndex(kv).IsValid() {
+			missing = append(missing, k)
+		}
+	}
+	if c.subset {
+		return missing, nil, nil
+	}
+	for _, kv := range m.MapKeys() {
+		if k := kv.Interface(); !wanted[k] {
+			extra = append(extra, k)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool {
+		return fmt.Sprintf("%#v", extra[i]) < fmt.Sprintf("%#v", extra[j])
+	})
+	return missing, extra, nil
+}
+
+// MapSlicesUnorderedEqual is a Checker checking that the provided map of
+// slices, for instance a map[string][]string, has the same keys as the
+// expected one, and that the slices associated with each key have the same
+// elements, compared using deep equality. The order of the elements in the
+// slices, and whether they are duplicated, are not relevant, so that each
+// slice is treated as a set. This is useful for comparing multi-valued maps
+// like HTTP headers or query parameters.
+// For instance:
+//
+//     c.Assert(req.URL.Query(), qt.MapSlicesUnorderedEqual, url.Values{
+//         "tag": {"wolf", "bad"},
+//     })
+//
+// On failure, the first key, in string order, whose values differ is
+// reported, along with the missing and extra elements.
+var MapSlicesUnorderedEqual Checker = &mapSlicesUnorderedEqualChecker{
+	numArgs: 1,
+}
+
+type mapSlicesUnorderedEqualChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got and args[0] are maps
+// with the same keys, whose values have the same elements.
+func (c *mapSlicesUnorderedEqualChecker) Check(got interface{}, args []interface{}) error {
+	want := args[0]
+	g, w := reflect.ValueOf(got), reflect.ValueOf(want)
+	if !isMapOfSlices(g) {
+		return BadCheckf("expected a map of slices, got %T instead", got)
+	}
+	if !isMapOfSlices(w) {
+		return BadCheckf("expected value is of type %T, not a map of slices", want)
+	}
+	if !w.Type().Key().ConvertibleTo(g.Type().Key()) {
+		return BadCheckf("cannot compare map keys of type %s with map keys of type %s", g.Type().Key(), w.Type().Key())
+	}
+	keys := make(map[interface{}]reflect.Value, g.Len()+w.Len())
+	for _, k := range g.MapKeys() {
+		keys[k.Interface()] = k
+	}
+	for _, k := range w.MapKeys() {
+		k = k.Convert(g.Type().Key())
+		keys[k.Interface()] = k
+	}
+	sorted := make([]reflect.Value, 0, len(keys))
+	for _, k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return fmt.Sprintf("%#v", sorted[i].Interface()) < fmt.Sprintf("%#v", sorted[j].Interface())
+	})
+	for _, k := range sorted {
+		gv, wv := g.MapIndex(k), w.MapIndex(k.Convert(w.Type().Key()))
+		if !gv.IsValid() {
+			return fmt.Errorf("key %#v not found in the provided map:\n(want)\n\t%#v", k.Interface(), wv.Interface())
+		}
+		if !wv.IsValid() {
+			return fmt.Errorf("unexpected key %#v found in the provided map:\n(got)\n\t%#v", k.Interface(), gv.Interface())
+		}
+		missing, extra := elementsDiff(gv, wv), elementsDiff(wv, gv)
+		if len(missing) == 0 && len(extra) == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("values are not equal as sets for key %#v:", k.Interface())
+		if len(missing) > 0 {
+			msg += "\n(missing)" + formatElements(missing)
+		}
+		if len(extra) > 0 {
+			msg += "\n(extra)" + formatElements(extra)
+		}
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] are maps
+// with different keys, or whose values have different elements.
+func (c *mapSlicesUnorderedEqualChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "maps have the same values as sets:\n(got)\n\t%#v\n(want)\n\t%#v", got, args[0])
+}
+
+// isMapOfSlices reports whether the given value is a map whose values are
+// slices or arrays.
+func isMapOfSlices(v reflect.Value) bool {
+	if v.Kind() != reflect.Map {
+		return false
+	}
+	k := v.Type().Elem().Kind()
+	return k == reflect.Slice || k == reflect.Array
+}
+
+// elementsDiff returns the elements of the want slice or array not included,
+// according to deep equality, in the got slice or array. Duplicates are only
+// returned once.
+func elementsDiff(got, want reflect.Value) []interface{} {
+	var diff []interface{}
+	for i := 0; i < want.Len(); i++ {
+		elem := want.Index(i).Interface()
+		if containsElement(got, elem) || containsElement(reflect.ValueOf(diff), elem) {
+			continue
+		}
+		diff = append(diff, elem)
+	}
+	return diff
+}
+
+// containsElement reports whether the given slice or array includes the
+// given element, according to deep equality.
+func containsElement(v reflect.Value, elem interface{}) bool {
+	for i := 0; i < v.Len(); i++ {
+		if deepEqual(v.Index(i).Interface(), elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueLen returns the length of the given value, which must be an array,
+// channel, map, slice or string.
+func valueLen(v interface{}) (int, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len(), nil
+	}
+	return 0, BadCheckf("expected a type with a length, got %T instead", v)
+}
+
+// Between returns a Checker checking that the provided numeric value is
+// within the given range, bounds included. The got value and the bounds can be
+// of any integer or floating point type.
+// For instance:
+//
+//     c.Assert(latency, qt.Between(0, 100*time.Millisecond))
+//     c.Assert(ratio, qt.Between(0.5, 1))
+//
+func Between(min, max interface{}) Checker {
+	return &betweenChecker{
+		min: min,
+		max: max,
+	}
+}
+
+// BetweenExclusive is like Between, but the provided value must be strictly
+// greater than min and strictly less than max.
+// For instance:
+//
+//     c.Assert(f, qt.BetweenExclusive(0, 1))
+//
+func BetweenExclusive(min, max interface{}) Checker {
+	return &betweenChecker{
+		min:       min,
+		max:       max,
+		exclusive: true,
+	}
+}
+
+type betweenChecker struct {
+	numArgs
+	min, max  interface{}
+	exclusive bool
+}
+
+// Check implements Checker.Check by checking that min <= got <= max, or that
+// min < got < max when the bounds are exclusive.
+func (c *betweenChecker) Check(got interface{}, args []interface{}) error {
+	if !isNumber(c.min) || !isNumber(c.max) {
+		return BadCheckf("range bounds must be numeric, got %T and %T instead", c.min, c.max)
+	}
+	if !isNumber(got) {
+		return BadCheckf("expected a numeric value, got %T instead", got)
+	}
+	if order, ok := compareNumbers(c.min, c.max); !ok || order > 0 {
+		return BadCheckf("invalid range %s: min is greater than max", c.rangeString())
+	}
+	if !c.contains(got) {
+		return fmt.Errorf("value is not in the range %s:\n(value)\n\t%#v", c.rangeString(), got)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is outside the range.
+func (c *betweenChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "value is in the range %s:\n(value)\n\t%#v", c.rangeString(), got)
+}
+
+// contains reports whether the given number lies within the range.
+func (c *betweenChecker) contains(v interface{}) bool {
+	lower, ok := compareNumbers(c.min, v)
+	if !ok {
+		return false
+	}
+	upper, ok := compareNumbers(v, c.max)
+	if !ok {
+		return false
+	}
+	if c.exclusive {
+		return lower < 0 && upper < 0
+	}
+	return lower <= 0 && upper <= 0
+}
+
+// rangeString returns the range in interval notation.
+func (c *betweenChecker) rangeString() string {
+	if c.exclusive {
+		return fmt.Sprintf("(%v, %v)", c.min, c.max)
+	}
+	return fmt.Sprintf("[%v, %v]", c.min, c.max)
+}
+
+// InBucket returns a Checker checking that the provided numeric value falls
+// within exactly one of the given named buckets. Each bucket is a [lo, hi]
+// range, bounds included. The got value can be of any integer or floating
+// point type. This is useful for testing classification logic, for instance
+// histogram bucketing.
+// For instance:
+//
+//     c.Assert(int(latency/time.Millisecond), qt.InBucket(map[string][2]float64{
+//         "fast":   {0, 99},
+//         "medium": {100, 999},
+//         "slow":   {1000, math.Inf(1)},
+//     }))
+//
+// Since bounds are included, adjacent buckets must not share a bound: a value
+// equal to the shared bound would fall within both buckets, and the check
+// would fail.
+// On failure, the value and all the buckets are reported, together with the
+// buckets including the value, if any.
+func InBucket(buckets map[string][2]float64) Checker {
+	return &inBucketChecker{
+		buckets: buckets,
+	}
+}
+
+type inBucketChecker struct {
+	numArgs
+	buckets map[string][2]float64
+}
+
+// Check implements Checker.Check by checking that got falls within exactly
+// one of the buckets.
+func (c *inBucketChecker) Check(got interface{}, args []interface{}) error {
+	matching, err := c.matching(got)
+	if err != nil {
+		return err
+	}
+	switch len(matching) {
+	case 0:
+		return fmt.Errorf("value is not in any bucket:\n(value)\n\t%#v\n(buckets)%s", got, c.format())
+	case 1:
+		return nil
+	}
+	return fmt.Errorf("value is in more than one bucket:\n(value)\n\t%#v\n(matching buckets)%s\n(buckets)%s", got, formatElements(matching), c.format())
+}
+
+// Negate implements Checker.Negate by checking that got does not fall within
+// exactly one of the buckets.
+func (c *inBucketChecker) Negate(got interface{}, args []interface{}) error {
+	matching, err := c.matching(got)
+	if err != nil {
+		return err
+	}
+	if len(matching) != 1 {
+		return nil
+	}
+	return fmt.Errorf("value is in bucket %q, but should not:\n(value)\n\t%#v\n(buckets)%s", matching[0], got, c.format())
+}
+
+// matching returns the sorted names of the buckets including got.
+func (c *inBucketChecker) matching(got interface{}) ([]interface{}, error) {
+	if len(c.buckets) == 0 {
+		return nil, BadCheckf("no buckets provided")
+	}
+	if !isNumber(got) {
+		return nil, BadCheckf("expected a numeric value, got %T instead", got)
+	}
+	var matching []interface{}
+	for _, name := range c.names() {
+		b := c.buckets[name]
+		if !(b[0] <= b[1]) {
+			return nil, BadCheckf("invalid bucket %q [%v, %v]: lo is greater than hi", name, b[0], b[1])
+		}
+		r := &betweenChecker{min: b[0], max: b[1]}
+		if r.contains(got) {
+			matching = append(matching, name)
+		}
+	}
+	return matching, nil
+}
+
+// names returns the bucket names in sorted order.
+func (c *inBucketChecker) names() []string {
+	names := make([]string, 0, len(c.buckets))
+	for name := range c.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// format returns the buckets formatted one per line, sorted by name.
+func (c *inBucketChecker) format() string {
+	var s string
+	for _, name := range c.names() {
+		b := c.buckets[name]
+		s += fmt.Sprintf("\n\t%q: [%v, %v]", name, b[0], b[1])
+	}
+	return s
+}
+
+// IsPositive is a Checker checking that the provided number, of any integer
+// or floating point type, is greater than zero.
+// For instance:
+//
+//     c.Assert(n, qt.IsPositive)
+//
+var IsPositive Checker = &signChecker{
+	name:     "positive",
+	opposite: "not positive",
+	ok: func(order int) bool {
+		return order > 0
+	},
+}
+
+// IsNegative is a Checker checking that the provided number, of any integer
+// or floating point type, is less than zero. Unsigned integers are never
+// negative.
+// For instance:
+//
+//     c.Assert(delta, qt.IsNegative)
+//
+var IsNegative Checker = &signChecker{
+	name:     "negative",
+	opposite: "not negative",
+	ok: func(order int) bool {
+		return order < 0
+	},
+}
+
+// IsNonZero is a Checker checking that the provided number, of any integer
+// or floating point type, is not zero. Like with the != operator, NaN is
+// considered not zero.
+// For instance:
+//
+//     c.Assert(count, qt.IsNonZero)
+//
+var IsNonZero Checker = &signChecker{
+	name:     "non-zero",
+	opposite: "zero",
+	ok: func(order int) bool {
+		return order != 0
+	},
+	nan: true,
+}
+
+type signChecker struct {
+	numArgs
+	// name and opposite hold the descriptions of the expected sign and of
+	// its opposite.
+	name, opposite string
+	// ok reports whether a number is accepted given its order relative to
+	// zero, as returned by compareNumbers.
+	ok func(order int) bool
+	// nan holds whether NaN is accepted.
+	nan bool
+}
+
+// Check implements Checker.Check by checking that got is a number with the
+// expected sign.
+func (c *signChecker) Check(got interface{}, args []interface{}) error {
+	if !isNumber(got) {
+		return BadCheckf("expected a numeric value, got %T instead", got)
+	}
+	if c.accepts(got) {
+		return nil
+	}
+	return fmt.Errorf("value is %s:\n(value)\n\t%v", c.opposite, got)
+}
+
+// Negate implements Checker.Negate by checking that got is a number without
+// the expected sign.
+func (c *signChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "value is %s:\n(value)\n\t%v", c.name, got)
+}
+
+// accepts reports whether the given number has the expected sign.
+func (c *signChecker) accepts(v interface{}) bool {
+	order, ok := compareNumbers(v, 0)
+	if !ok {
+		// The value is NaN.
+		return c.nan
+	}
+	return c.ok(order)
+}
+
+// IsFinite is a Checker checking that the provided floating point number is
+// neither NaN nor infinite.
+// For instance:
+//
+//     c.Assert(mean, qt.IsFinite)
+//
+var IsFinite Checker = &isFiniteChecker{}
+
+type isFiniteChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is a finite float.
+func (c *isFiniteChecker) Check(got interface{}, args []interface{}) error {
+	var f float64
+	switch v := got.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	default:
+		return BadCheckf("expected a floating point value, got %T instead", got)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("the provided value is not finite:\n(value)\n\t%#v", got)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is a NaN or infinite
+// float.
+func (c *isFiniteChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value is finite:\n(value)\n\t%#v", got)
+}
+
+// IsSorted is a Checker checking that the provided slice or array of numbers
+// or strings is sorted in non-decreasing order. Empty and single element
+// slices are considered sorted.
+// For instance:
+//
+//     c.Assert([]int{1, 1, 2, 3}, qt.IsSorted)
+//     c.Assert(names, qt.IsSorted)
+//
+// Use IsSortedBy to check slices of other types.
+var IsSorted Checker = &isSortedChecker{}
+
+// IsSortedBy returns a Checker checking that the provided slice or array is
+// sorted according to the given less function, which must be of type
+// func(a, b T) bool, where T is the slice element type.
+// For instance:
+//
+//     c.Assert(people, qt.IsSortedBy(func(a, b Person) bool {
+//         return a.Age < b.Age
+//     }))
+//
+func IsSortedBy(less interface{}) Checker {
+	return &isSortedChecker{
+		less: less,
+	}
+}
+
+type isSortedChecker struct {
+	numArgs
+	less interface{}
+}
+
+// Check implements Checker.Check by checking that got is sorted.
+func (c *isSortedChecker) Check(got interface{}, args []interface{}) error {
+	v := reflect.ValueOf(got)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return BadCheckf("expected a slice or an array, got %T instead", got)
+	}
+	less, err := c.lessFunc(v.Type().Elem())
+	if err != nil {
+		return err
+	}
+	for i := 1; i < v.Len(); i++ {
+		prev, current := v.Index(i-1), v.Index(i)
+		if less(current, prev) {
+			return fmt.Errorf(
+				"the provided value is not sorted: element %d is less than element %d:\n(value)\n\t%#v\n(element %d)\n\t%#v\n(element %d)\n\t%#v",
+				i, i-1, got, i-1, prev.Interface(), i, current.Interface())
+		}
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got is not sorted.
+func (c *isSortedChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value is sorted:\n(value)\n\t%#v", got)
+}
+
+// lessFunc returns a function reporting whether a value of the given type is
+// less than another one.
+func (c *isSortedChecker) lessFunc(t reflect.Type) (func(a, b reflect.Value) bool, error) {
+	if c.less == nil {
+		switch t.Kind() {
+		case reflect.String:
+			return func(a, b reflect.Value) bool {
+				return a.String() < b.String()
+			}, nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			return func(a, b reflect.Value) bool {
+				order, ok := compareNumbers(a.Interface(), b.Interface())
+				return ok && order < 0
+			}, nil
+		}
+		return nil, BadCheckf("cannot sort elements of type %s without a less function", t)
+	}
+	f := reflect.ValueOf(c.less)
+	ftype := f.Type()
+	if ftype.Kind() != reflect.Func || ftype.NumIn() != 2 || ftype.NumOut() != 1 || ftype.Out(0).Kind() != reflect.Bool {
+		return nil, BadCheckf("less function must be of type func(a, b T) bool, got %T instead", c.less)
+	}
+	if f.IsNil() {
+		return nil, BadCheckf("less function of type %T is nil", c.less)
+	}
+	if !t.AssignableTo(ftype.In(0)) || !t.AssignableTo(ftype.In(1)) {
+		return nil, BadCheckf("less function of type %T cannot compare elements of type %s", c.less, t)
+	}
+	return func(a, b reflect.Value) bool {
+		return f.Call([]reflect.Value{a, b})[0].Bool()
+	}, nil
+}
+
+// IsUnique is a Checker checking that the elements of the provided slice or
+// array are distinct, according to deep equality. Empty and single element
+// slices are considered unique.
+// For instance:
+//
+//     c.Assert(ids, qt.IsUnique)
+//
+// On failure, the first duplicated element and the indices at which it occurs
+// are reported.
+var IsUnique Checker = &isUniqueChecker{}
+
+type isUniqueChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got has no duplicate
+// elements.
+func (c *isUniqueChecker) Check(got interface{}, args []interface{}) error {
+	v := reflect.ValueOf(got)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return BadCheckf("expected a slice or an array, got %T instead", got)
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i).Interface()
+		indices := []int{i}
+		for j := i + 1; j < v.Len(); j++ {
+			if deepEqual(elem, v.Index(j).Interface()) {
+				indices = append(indices, j)
+			}
+		}
+		if len(indices) > 1 {
+			return fmt.Errorf("the provided value has duplicate elements:\n(value)\n\t%#v\n(duplicate)\n\t%#v\n(indices)\n\t%v", got, elem, indices)
+		}
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got has at least one
+// duplicate element.
+func (c *isUniqueChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value has unique elements:\n(value)\n\t%#v", got)
+}
+
+// deepEqual reports whether the given values are deeply equal. Values that
+// cannot be compared by go-cmp, for instance structs with unexported fields,
+// are compared using reflect.DeepEqual.
+func deepEqual(x, y interface{}) (equal bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			equal = reflect.DeepEqual(x, y)
+		}
+	}()
+	return cmp.Equal(x, y)
+}
+
+// ContainsMap returns a Checker checking that the provided map contains all
+// the keys in the given subset map, and that their values are deeply equal.
+// Keys present in the provided map but not in the subset are ignored.
+// For instance:
+//
+//     c.Assert(headers, qt.ContainsMap(map[string]string{
+//         "Content-Type": "application/json",
+//     }))
+//
+func ContainsMap(subset interface{}) Checker {
+	return &containsMapChecker{
+		subset: subset,
+	}
+}
+
+type containsMapChecker struct {
+	numArgs
+	subset interface{}
+}
+
+// Check implements Checker.Check by checking that all the entries in the
+// stored subset are present in got.
+func (c *containsMapChecker) Check(got interface{}, args []interface{}) error {
+	subset := reflect.ValueOf(c.subset)
+	if subset.Kind() != reflect.Map {
+		return BadCheckf("expected subset is not a map, got %T instead", c.subset)
+	}
+	v := reflect.ValueOf(got)
+	if v.Kind() != reflect.Map {
+		return BadCheckf("expected a map, got %T instead", got)
+	}
+	if !subset.Type().Key().AssignableTo(v.Type().Key()) || !subset.Type().Elem().AssignableTo(v.Type().Elem()) {
+		return BadCheckf("cannot compare map of type %T with subset of type %T", got, c.subset)
+	}
+	keys := subset.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i].Interface()) < fmt.Sprintf("%#v", keys[j].Interface())
+	})
+	for _, key := range keys {
+		want := subset.MapIndex(key).Interface()
+		value := v.MapIndex(key)
+		if !value.IsValid() {
+			return fmt.Errorf("key %#v not found in map:\n(value)\n\t%#v", key.Interface(), got)
+		}
+		if !deepEqual(value.Interface(), want) {
+			return &notEqualError{
+				msg:  fmt.Sprintf("map value mismatch for key %#v", key.Interface()),
+				got:  value.Interface(),
+				want: want,
+			}
+		}
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that at least one entry in the
+// stored subset is missing from got or has a different value.
+func (c *containsMapChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided map contains all the expected entries:\n(value)\n\t%#v", got)
+}
+
+// ContainsInOrder returns a Checker checking that the provided slice or array
+// contains the given elements in the given order, not necessarily
+// contiguously, so that other elements may appear between them. Elements are
+// compared using deep equality.
+// For instance:
+//
+//     c.Assert(events, qt.ContainsInOrder("connect", "login", "logout"))
+//
+// On failure, the first element that cannot be found after the previous
+// match is reported.
+func ContainsInOrder(elems ...interface{}) Checker {
+	return &containsInOrderChecker{
+		elems: elems,
+	}
+}
+
+type containsInOrderChecker struct {
+	numArgs
+	elems []interface{}
+}
+
+// Check implements Checker.Check by checking that the stored elements are a
+// subsequence of got.
+func (c *containsInOrderChecker) Check(got interface{}, args []interface{}) error {
+	v := reflect.ValueOf(got)
+	if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+		return BadCheckf("expected a slice or an array, got %T instead", got)
+	}
+	// The index of the previous match, and the one to start searching from.
+	matched, next := -1, 0
+	for i, elem := range c.elems {
+		for ; next < v.Len(); next++ {
+			if deepEqual(v.Index(next).Interface(), elem) {
+				break
+			}
+		}
+		if next < v.Len() {
+			matched, next = next, next+1
+			continue
+		}
+		if i == 0 {
+			return fmt.Errorf("element %#v not found:\n(value)\n\t%#v", elem, got)
+		}
+		return fmt.Errorf("element %#v not found after element %#v at index %d:\n(value)\n\t%#v", elem, c.elems[i-1], matched, got)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that the stored elements are
+// not a subsequence of got.
+func (c *containsInOrderChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value contains the elements in order:\n(elements)%s\n(value)\n\t%#v", formatElements(c.elems), got)
 }
 
-// CmpEquals returns a Checker checking equality of two arbitrary values
-// according to the provided compare options. See DeepEquals as an example of
-// such a checker, commonly used when no compare options are required.
-// For instance:
+// ContainsMatch returns a Checker checking that at least one element of the
+// provided slice or array satisfies the given checker, called with the given
+// arguments. Optionally, a non-nil pointer can be provided as argument to the
+// check, in which case the first matching element is stored into the pointed
+// value, so that it can be further checked. For instance:
 //
-//     c.Assert(list, qt.CmpEquals(cmpopts.SortSlices), []int{42, 47})
-//     c.Assert(got, qt.CmpEquals(), []int{42, 47}) // Same as qt.DeepEquals.
+//     var user User
+//     c.Assert(users, qt.ContainsMatch(qt.FieldEquals("Name", "bad wolf")), &user)
+//     c.Assert(user.Admin, qt.Equals, true)
 //
-func CmpEquals(opts ...cmp.Option) Checker {
-	return &cmpEqualsChecker{
-		numArgs: 1,
-		opts:    opts,
+// When no elements match, the failures of the checker for all the elements are
+// reported.
+func ContainsMatch(checker Checker, args ...interface{}) Checker {
+	return &containsMatchChecker{
+		checker: checker,
+		args:    args,
 	}
 }
 
-type cmpEqualsChecker struct {
+type containsMatchChecker struct {
 	numArgs
-	opts cmp.Options
+	checker Checker
+	args    []interface{}
 }
 
-// Check implements Checker.Check by checking that got == args[0] according to
-// the compare options stored in the checker.
-func (c *cmpEqualsChecker) Check(got interface{}, args []interface{}) (err error) {
-	defer func() {
-		// A panic is raised in some cases, for instance when trying to compare
-		// structs with unexported fields and neither AllowUnexported nor
-		// cmpopts.IgnoreUnexported are provided.
-		if r := recover(); r != nil {
-			err = fmt.Errorf("%s", r)
+// Check implements Checker.Check by checking that an element of got satisfies
+// the stored checker, and storing it into args[0] if provided.
+func (c *containsMatchChecker) Check(got interface{}, args []interface{}) error {
+	i, failures, err := c.match(got, args)
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		msg := fmt.Sprintf("no element matches the checker:\n(value)\n\t%#v", got)
+		if len(failures) != 0 {
+			msg += "\n(failures)"
+			for j, failure := range failures {
+				msg += fmt.Sprintf("\n\telement %d:\n%s", j, indent(failure, "\t\t"))
+			}
 		}
-	}()
-	want := args[0]
-	if diff := cmp.Diff(got, want, c.opts...); diff != "" {
-		return fmt.Errorf("values are not equal:\n%s%s", notEqualErrorPrefix, strings.TrimSuffix(diff, "\n"))
+		return errors.New(msg)
+	}
+	if len(args) == 1 {
+		reflect.ValueOf(args[0]).Elem().Set(reflect.ValueOf(got).Index(i))
 	}
 	return nil
 }
 
-// Negate implements Checker.Negate by checking that got != args[0] according
-// to the compare options stored in the checker.
-func (c *cmpEqualsChecker) Negate(got interface{}, args []interface{}) error {
-	if c.Check(got, args) != nil {
+// Negate implements Checker.Negate by checking that no element of got
+// satisfies the stored checker.
+func (c *containsMatchChecker) Negate(got interface{}, args []interface{}) error {
+	i, _, err := c.match(got, args)
+	if err != nil {
+		return err
+	}
+	if i < 0 {
 		return nil
 	}
-	return fmt.Errorf("both values deeply equal %#v, but should not", got)
+	return fmt.Errorf("element %d matches the checker, but should not:\n(value)\n\t%#v\n(element)\n\t%#v", i, got, reflect.ValueOf(got).Index(i).Interface())
 }
 
-// DeepEquals is a Checker deeply checking equality of two arbitrary values.
-// For instance:
-//
-//     c.Assert(got, qt.DeepEquals, []int{42, 47})
-//
-var DeepEquals = CmpEquals()
+// Variadic implements VariadicChecker by accepting an optional pointer used
+// to store the matching element.
+func (c *containsMatchChecker) Variadic() bool {
+	return true
+}
 
-// Matches is a Checker checking that the provided string, or the string
-// representation of the provided value, matches the provided regular
-// expression pattern.
+// match returns the index of the first element of got satisfying the stored
+// checker, or -1 and the failure messages for all the elements if there is no
+// such element.
+func (c *containsMatchChecker) match(got interface{}, args []interface{}) (int, []string, error) {
+	if checkerIsNil(c.checker) {
+		return -1, nil, BadCheckf("nil checker provided")
+	}
+	if n := c.checker.NumArgs(); len(c.args) < n || len(c.args) > n && !isVariadic(c.checker) {
+		return -1, nil, BadCheckf("invalid number of arguments provided to the checker: got %d, want %d", len(c.args), n)
+	}
+	v := reflect.ValueOf(got)
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return -1, nil, BadCheckf("expected a slice or an array, got %T instead", got)
+	}
+	switch len(args) {
+	case 0:
+	case 1:
+		out := reflect.ValueOf(args[0])
+		if out.Kind() != reflect.Ptr || out.IsNil() {
+			return -1, nil, BadCheckf("expected a non-nil pointer to store the matching element, got %T instead", args[0])
+		}
+		if !v.Type().Elem().AssignableTo(out.Type().Elem()) {
+			return -1, nil, BadCheckf("cannot store an element of type %s into %T", v.Type().Elem(), args[0])
+		}
+	default:
+		return -1, nil, BadCheckf("too many arguments provided to checker: got %d, want at most 1", len(args))
+	}
+	failures := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		err := c.checker.Check(v.Index(i).Interface(), c.args)
+		if IsBadCheck(err) {
+			return -1, nil, err
+		}
+		if err == nil {
+			return i, nil, nil
+		}
+		failures = append(failures, err.Error())
+	}
+	return -1, failures, nil
+}
+
+// OneOf returns a Checker checking that the provided value is deeply equal to
+// one of the given options. This is clearer than a regular expression
+// alternation for enumerated values, and works with values of any type.
 // For instance:
 //
-//     c.Assert("these are the voyages", qt.Matches, "these are .*")
-//     c.Assert(net.ParseIP("1.2.3.4"), qt.Matches, "1.*")
+//     c.Assert(resp.StatusCode, qt.OneOf(http.StatusOK, http.StatusNoContent))
 //
-var Matches Checker = &matchesChecker{
-	numArgs: 1,
+// On failure, the value and all the options are reported.
+func OneOf(options ...interface{}) Checker {
+	return &oneOfChecker{
+		options: options,
+	}
 }
 
-type matchesChecker struct {
+type oneOfChecker struct {
 	numArgs
+	options []interface{}
 }
 
-// Check implements Checker.Check by checking that got is a string or a
-// fmt.Stringer and that it matches args[0].
-func (c *matchesChecker) Check(got interface{}, args []interface{}) error {
-	pattern := args[0]
-	switch v := got.(type) {
-	case string:
-		return match(v, pattern, "string mismatch")
-	case fmt.Stringer:
-		return match(v.String(), pattern, "fmt.Stringer mismatch")
+// Check implements Checker.Check by checking that got is deeply equal to one
+// of the stored options.
+func (c *oneOfChecker) Check(got interface{}, args []interface{}) error {
+	i, err := c.index(got)
+	if err != nil {
+		return err
 	}
-	return BadCheckf("did not get an string or a fmt.Stringer, got %T instead", got)
+	if i >= 0 {
+		return nil
+	}
+	return fmt.Errorf("value is not one of the expected options:\n(value)\n\t%#v\n(options)%s", got, formatElements(c.options))
 }
 
-// Negate implements Checker.Negate by checking that got is a string or a
-// fmt.Stringer and that it does not match args[0].
-func (c *matchesChecker) Negate(got interface{}, args []interface{}) error {
-	err := c.Check(got, args)
-	if IsBadCheck(err) {
+// Negate implements Checker.Negate by checking that got is not deeply equal
+// to any of the stored options.
+func (c *oneOfChecker) Negate(got interface{}, args []interface{}) error {
+	i, err := c.index(got)
+	if err != nil {
 		return err
 	}
-	if err != nil {
+	if i < 0 {
 		return nil
 	}
-	pattern := args[0]
-	return fmt.Errorf("%q matches %q, but should not", got, pattern)
+	return fmt.Errorf("value is one of the given options, but should not:\n(value)\n\t%#v\n(matching option)\n\t%d: %#v", got, i, c.options[i])
 }
 
-// ErrorMatches is a Checker checking that the provided value is an error whose
-// message matches the provided regular expression pattern.
+// index returns the index of the first stored option deeply equal to got, or
+// -1 if there is no such option.
+func (c *oneOfChecker) index(got interface{}) (int, error) {
+	if len(c.options) == 0 {
+		return -1, BadCheckf("no options provided")
+	}
+	for i, option := range c.options {
+		if deepEqual(got, option) {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// PrefixEquals returns a Checker checking that the first elements of the
+// provided slice or array are deeply equal to the given want slice, ignoring
+// any further elements. This is useful when only the beginning of a result is
+// stable or relevant, for instance with paginated or streamed results.
 // For instance:
 //
-//     c.Assert(err, qt.ErrorMatches, "bad wolf .*")
+//     c.Assert(results, qt.PrefixEquals([]string{"first", "second"}))
 //
-var ErrorMatches Checker = &errorMatchesChecker{
-	numArgs: 1,
+// On failure, the differences in the prefix region are reported.
+func PrefixEquals(want interface{}) Checker {
+	return &prefixEqualsChecker{
+		want: want,
+	}
 }
 
-type errorMatchesChecker struct {
+type prefixEqualsChecker struct {
 	numArgs
+	want interface{}
 }
 
-// Check implements Checker.Check by checking that got is an error whose
-// Error() matches args[0].
-func (c *errorMatchesChecker) Check(got interface{}, args []interface{}) error {
-	pattern := args[0]
-	err, ok := got.(error)
-	if !ok {
-		return BadCheckf("did not get an error, got %T instead", got)
+// Check implements Checker.Check by checking that got starts with the
+// elements in the stored slice.
+func (c *prefixEqualsChecker) Check(got interface{}, args []interface{}) error {
+	w := reflect.ValueOf(c.want)
+	if w.Kind() != reflect.Slice {
+		return BadCheckf("expected prefix must be a slice, got %T instead", c.want)
 	}
-	if err == nil {
-		return fmt.Errorf("error is nil, therefore it does not match %q", pattern)
+	v := reflect.ValueOf(got)
+	switch v.Kind() {
+	case reflect.Slice:
+	case reflect.Array:
+		// Arrays must be addressable in order to be sliced.
+		a := reflect.New(v.Type()).Elem()
+		a.Set(v)
+		v = a
+	default:
+		return BadCheckf("expected a slice or an array, got %T instead", got)
 	}
-	return match(err.Error(), pattern, "error message mismatch")
-}
-
-// Negate implements Checker.Negate by checking that got is either nil or
-// an error whose String() does not match args[0].
-func (c *errorMatchesChecker) Negate(got interface{}, args []interface{}) error {
-	err := c.Check(got, args)
-	if IsBadCheck(err) {
-		return err
+	if v.Len() < w.Len() {
+		return fmt.Errorf("value is shorter than the expected prefix:\n(length)\n\t%d\n(prefix length)\n\t%d\n(value)\n\t%#v", v.Len(), w.Len(), got)
 	}
-	if err != nil {
+	if w.Len() == 0 {
+		// Avoid reporting differences between nil and empty slices.
 		return nil
 	}
-	pattern := args[0]
-	return fmt.Errorf("error %q matches %q, but should not", got, pattern)
+	prefix := v.Slice(0, w.Len()).Interface()
+	if !deepEqual(prefix, c.want) {
+		return fmt.Errorf("values are not equal in the first %d elements:\n%s", w.Len(), valuesDiff(prefix, c.want, "got", "want"))
+	}
+	return nil
 }
 
-// PanicMatches is a Checker checking that the provided function panics with a
-// message matching the provided regular expression pattern.
+// Negate implements Checker.Negate by checking that got does not start with
+// the elements in the stored slice.
+func (c *prefixEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "the provided value starts with the given elements:\n(prefix)\n\t%#v\n(value)\n\t%#v", c.want, got)
+}
+
+// Via returns a Checker that applies the given transform function to the
+// provided value before checking the result with the given checker. The
+// transform function must accept a single argument to which the provided value
+// is assignable, and return a single value.
 // For instance:
 //
-//     c.Assert(func() {panic("bad wolf ...")}, qt.PanicMatches, "bad wolf .*")
+//     c.Assert(person, qt.Via(func(p Person) string {
+//         return p.Name
+//     }, qt.Equals), "bad wolf")
 //
-var PanicMatches Checker = &panicMatchesChecker{
-	numArgs: 1,
+func Via(transform interface{}, checker Checker) Checker {
+	return &viaChecker{
+		Checker:   checker,
+		transform: transform,
+	}
 }
 
-type panicMatchesChecker struct {
-	numArgs
+type viaChecker struct {
+	Checker
+	transform interface{}
 }
 
-// Check implements Checker.Check by checking that got is a func() that panics
-// with a message matching args[0].
-func (c *panicMatchesChecker) Check(got interface{}, args []interface{}) (err error) {
-	f := reflect.ValueOf(got)
-	if f.Kind() != reflect.Func {
-		return BadCheckf("expected a function, got %T instead", got)
+// Check implements Checker.Check by checking that the transformed got value
+// satisfies the stored checker.
+func (c *viaChecker) Check(got interface{}, args []interface{}) error {
+	if checkerIsNil(c.Checker) {
+		return BadCheckf("nil checker provided")
 	}
-	ftype := f.Type()
-	if ftype.NumIn() != 0 {
-		return BadCheckf(
-			"expected a function accepting no arguments, got %T instead", got)
+	transformed, err := c.apply(got)
+	if err != nil {
+		return err
 	}
-
-	defer func() {
-		r := recover()
-		if r == nil {
-			err = fmt.Errorf("the function did not panic")
-			return
-		}
-		var msg string
-		if panicErr, ok := r.(error); ok {
-			msg = panicErr.Error()
-		} else {
-			msg = fmt.Sprintf("%s", r)
-		}
-		pattern := args[0]
-		err = match(msg, pattern, "panic message mismatch")
-	}()
-
-	f.Call(nil)
-	return nil
+	return c.annotate(c.Checker.Check(transformed, args), transformed)
 }
 
-// Negate implements Checker.Negate by checking that got is a func() that does
-// not panic with the given message.
-func (c *panicMatchesChecker) Negate(got interface{}, args []interface{}) error {
-	err := c.Check(got, args)
-	if IsBadCheck(err) {
-		return err
+// Negate implements Checker.Negate by checking that the transformed got value
+// does not satisfy the stored checker.
+func (c *viaChecker) Negate(got interface{}, args []interface{}) error {
+	if checkerIsNil(c.Checker) {
+		return BadCheckf("nil checker provided")
 	}
+	transformed, err := c.apply(got)
 	if err != nil {
-		return nil
+		return err
 	}
-	pattern := args[0]
-	return fmt.Errorf("there was a panic matching %q", pattern)
+	return c.annotate(c.Checker.Negate(transformed, args), transformed)
 }
 
-// IsNil is a Checker checking that the provided value is nil.
-// For instance:
-//
-//     c.Assert(got, qt.IsNil)
-//
-var IsNil Checker = &isNilChecker{}
+// Variadic implements VariadicChecker.Variadic by reporting whether the
+// stored checker is variadic.
+func (c *viaChecker) Variadic() bool {
+	return isVariadic(c.Checker)
+}
 
-type isNilChecker struct {
-	numArgs
+// withOptions implements optionsChecker by applying the given compare options
+// to the stored checker, if it supports them.
+func (c *viaChecker) withOptions(opts []cmp.Option) (Checker, bool) {
+	checker, ok := applyOptions(c.Checker, opts)
+	if !ok {
+		return nil, false
+	}
+	return Via(c.transform, checker), true
 }
 
-// Check implements Checker.Check by checking that got is nil.
-func (c *isNilChecker) Check(got interface{}, args []interface{}) (err error) {
-	if got == nil {
-		return nil
+// apply calls the transform function with the given value.
+func (c *viaChecker) apply(got interface{}) (interface{}, error) {
+	f := reflect.ValueOf(c.transform)
+	if f.Kind() != reflect.Func || f.Type().NumIn() != 1 || f.Type().NumOut() != 1 {
+		return nil, BadCheckf("transform must be a function accepting and returning a single value, got %T instead", c.transform)
 	}
-	value := reflect.ValueOf(got)
-	switch value.Kind() {
-	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
-		if value.IsNil() {
-			return nil
+	in := f.Type().In(0)
+	v := reflect.ValueOf(got)
+	if !v.IsValid() {
+		switch in.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			v = reflect.Zero(in)
+		default:
+			return nil, BadCheckf("cannot use nil as transform argument of type %s", in)
 		}
 	}
-	return fmt.Errorf("%#v is not nil", got)
+	if !v.Type().AssignableTo(in) {
+		return nil, BadCheckf("cannot use value of type %T as transform argument of type %s", got, in)
+	}
+	return f.Call([]reflect.Value{v})[0].Interface(), nil
 }
 
-// Negate implements Checker.Negate by checking that got is not nil.
-func (c *isNilChecker) Negate(got interface{}, args []interface{}) error {
-	if c.Check(got, args) != nil {
-		return nil
+// annotate adds information about the transformation to the given check
+// failure.
+func (c *viaChecker) annotate(err error, transformed interface{}) error {
+	if err == nil || IsBadCheck(err) {
+		return err
 	}
-	return errors.New("the value is nil, but should not")
+	return fmt.Errorf("%s\n(transformed by %T)\n\t%#v", err, c.transform, transformed)
 }
 
-// HasLen is a Checker checking that the provided value has the provided length.
+// WithTimeout returns a Checker which is like the given one, but which fails
+// if the given checker does not complete within the given timeout, instead of
+// blocking the test forever. This can be used to bound any checker that might
+// block, for instance CompletesWithin with a long deadline, or a custom
+// checker receiving from a channel.
 // For instance:
 //
-//     c.Assert([]int{42, 47}, qt.HasLen, 2)
-//     c.Assert(myMap, qt.HasLen, 42)
+//     c.Assert(results, qt.WithTimeout(time.Second, qt.ContainsInOrder("ok")))
 //
-var HasLen Checker = &hasLenChecker{
-	numArgs: 1,
+// Note that the given checker is run in its own goroutine, and it keeps
+// running in the background if it does not complete within the timeout: the
+// goroutine, and anything it references, is leaked until it completes.
+// Panics raised by the given checker are propagated to the caller.
+func WithTimeout(timeout time.Duration, checker Checker) Checker {
+	return &timeoutChecker{
+		Checker: checker,
+		timeout: timeout,
+	}
 }
 
-type hasLenChecker struct {
-	numArgs
+type timeoutChecker struct {
+	Checker
+	timeout time.Duration
 }
 
-// Check implements Checker.Check by checking that len(got) == args[0].
-func (c *hasLenChecker) Check(got interface{}, args []interface{}) (err error) {
-	want, ok := args[0].(int)
+// Check implements Checker.Check by checking that the stored checker
+// succeeds within the stored timeout.
+func (c *timeoutChecker) Check(got interface{}, args []interface{}) error {
+	return c.run(func() error {
+		return c.Checker.Check(got, args)
+	})
+}
+
+// Negate implements Checker.Negate by checking that the stored checker's
+// negation succeeds within the stored timeout.
+func (c *timeoutChecker) Negate(got interface{}, args []interface{}) error {
+	return c.run(func() error {
+		return c.Checker.Negate(got, args)
+	})
+}
+
+// Variadic implements VariadicChecker.Variadic by reporting whether the
+// stored checker is variadic.
+func (c *timeoutChecker) Variadic() bool {
+	return isVariadic(c.Checker)
+}
+
+// withOptions implements optionsChecker by applying the given compare options
+// to the stored checker, if it supports them.
+func (c *timeoutChecker) withOptions(opts []cmp.Option) (Checker, bool) {
+	checker, ok := applyOptions(c.Checker, opts)
 	if !ok {
-		return BadCheckf("expected length is of type %T, not int", args[0])
+		return nil, false
 	}
-	v := reflect.ValueOf(got)
-	switch v.Kind() {
-	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
-	default:
-		return BadCheckf("expected a type with a length, got %T instead", got)
-	}
-	if length := v.Len(); length != want {
-		return fmt.Errorf("the provided value has not the expected length of %d:\n(value)\n\t%#v\n(-got length +want length)\n\t-: %d\n\t+: %d", want, got, length, want)
-	}
-	return nil
+	return WithTimeout(c.timeout, checker), true
 }
 
-// Negate implements Checker.Negate by checking that len(got) != args[0].
-func (c *hasLenChecker) Negate(got interface{}, args []interface{}) error {
-	err := c.Check(got, args)
-	if IsBadCheck(err) {
-		return err
+// run calls the given function in its own goroutine, and waits for it to
+// return its result, for at most the stored timeout.
+func (c *timeoutChecker) run(f func() error) error {
+	type result struct {
+		err       error
+		recovered interface{}
+		panicked  bool
 	}
-	if err != nil {
-		return nil
+	done := make(chan result, 1)
+	go func() {
+		panicked := true
+		defer func() {
+			if panicked {
+				done <- result{recovered: recover(), panicked: true}
+			}
+		}()
+		err := f()
+		panicked = false
+		done <- result{err: err}
+	}()
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		if r.panicked {
+			panic(r.recovered)
+		}
+		return r.err
+	case <-timer.C:
+		return fmt.Errorf("checker did not complete within %v", c.timeout)
 	}
-	want := args[0].(int)
-	return fmt.Errorf("the provided value has a length of %d, but should not:\n(value)\n\t%#v", want, got)
 }
 
 // Not returns a Checker negating the given Checker.
@@ -356,6 +4378,11 @@ func (c *hasLenChecker) Negate(got interface{}, args []interface{}) error {
 //     c.Assert(got, qt.Not(qt.IsNil))
 //     c.Assert(answer, qt.Not(qt.Equals), 42)
 //
+// The returned checker accepts the same arguments as the given one, in the
+// same order, so that they are passed unchanged to the negated checker.
+// Errors reporting a misuse of the given checker, as created by BadCheckf,
+// are returned unchanged, so that negating a misused checker never results in
+// a successful check.
 func Not(checker Checker) Checker {
 	return &notChecker{
 		Checker: checker,
@@ -376,6 +4403,22 @@ func (c *notChecker) Negate(got interface{}, args []interface{}) error {
 	return c.Checker.Check(got, args)
 }
 
+// Variadic implements VariadicChecker.Variadic by reporting whether the
+// stored checker is variadic.
+func (c *notChecker) Variadic() bool {
+	return isVariadic(c.Checker)
+}
+
+// withOptions implements optionsChecker by negating the stored checker with
+// the given compare options applied, if it supports them.
+func (c *notChecker) withOptions(opts []cmp.Option) (Checker, bool) {
+	checker, ok := applyOptions(c.Checker, opts)
+	if !ok {
+		return nil, false
+	}
+	return Not(checker), true
+}
+
 // numArgs helps implementing Checker.NumArgs.
 type numArgs int
 
@@ -404,3 +4447,59 @@ func match(got string, pattern interface{}, msg string) error {
 		pattern: regex,
 	}
 }
+
+// isNumber reports whether the given value is of an integer or floating point
+// kind.
+func isNumber(v interface{}) bool {
+	_, ok := bigFloat(v)
+	return ok || isNaN(v)
+}
+
+// compareNumbers compares the given numeric values, possibly of different
+// types, and returns -1, 0 or +1 depending on whether x is less than, equal to
+// or greater than y. The ok return value is false if either value is not a
+// number or is NaN.
+func compareNumbers(x, y interface{}) (order int, ok bool) {
+	bx, ok := bigFloat(x)
+	if !ok {
+		return 0, false
+	}
+	by, ok := bigFloat(y)
+	if !ok {
+		return 0, false
+	}
+	return bx.Cmp(by), true
+}
+
+// bigFloat returns the given numeric value as a *big.Float without losing
+// precision. The ok return value is false if v is not a number or is NaN.
+func bigFloat(v interface{}) (f *big.Float, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(rv.Float()), true
+	}
+	return nil, false
+}
+
+// isNaN reports whether v is a floating point NaN value.
+func isNaN(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.IsNaN(rv.Float())
+	}
+	return false
+}
+
+// indent returns s with each line preceded by the given prefix.
+func indent(s, prefix string) string {
+	return prefix + strings.Replace(s, "\n", "\n"+prefix, -1)
+}
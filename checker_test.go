@@ -4,8 +4,20 @@ package quicktest_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unicode"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 
@@ -16,6 +28,102 @@ var sameInts = cmpopts.SortSlices(func(x, y int) bool {
 	return x < y
 })
 
+// canceledContext returns a context which has been canceled.
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// newInt returns a pointer to the given int.
+func newInt(n int) *int {
+	return &n
+}
+
+// bigIntEquals compares two *big.Int values by value.
+func bigIntEquals(x, y *big.Int) bool {
+	return x.Cmp(y) == 0
+}
+
+// namedBool is a boolean type which cannot be returned by comparers.
+type namedBool bool
+
+var (
+	goodTime      = time.Date(2012, 3, 28, 0, 0, 0, 0, time.UTC)
+	otherZoneTime = goodTime.In(time.FixedZone("+0100", 60*60))
+)
+
+// caseInsensitive is a string type with an Equal method ignoring case.
+type caseInsensitive string
+
+func (s caseInsensitive) Equal(other caseInsensitive) bool {
+	return strings.EqualFold(string(s), string(other))
+}
+
+// pointerEqual is a type with an Equal method defined on its pointer.
+type pointerEqual struct {
+	n int
+}
+
+func (p *pointerEqual) Equal(other *pointerEqual) bool {
+	return p.n == other.n
+}
+
+// verboseError is an error with a different verbose representation.
+type verboseError struct {
+	msg     string
+	verbose string
+}
+
+func (e *verboseError) Error() string {
+	return e.msg
+}
+
+func (e *verboseError) Format(f fmt.State, verb rune) {
+	if f.Flag('+') {
+		io.WriteString(f, e.verbose)
+		return
+	}
+	io.WriteString(f, e.msg)
+}
+
+// wrappingError is an error wrapping another error.
+type wrappingError struct {
+	msg string
+	err error
+}
+
+func (e *wrappingError) Error() string {
+	return e.msg + ": " + e.err.Error()
+}
+
+func (e *wrappingError) Unwrap() error {
+	return e.err
+}
+
+// companion is a struct used to test partial matches.
+type companion struct {
+	Name   string
+	Age    int
+	Tags   []string
+	secret string
+}
+
+// crew is a struct used to test nested field access.
+type xmlUser struct {
+	XMLName xml.Name `xml:"user"`
+	ID      int      `xml:"id,attr"`
+	Name    string   `xml:"name"`
+}
+
+type crew struct {
+	Captain *companion
+	Ship    struct {
+		Name string
+	}
+	Size int
+}
+
 var checkerTests = []struct {
 	about                 string
 	checker               qt.Checker
@@ -41,12 +149,90 @@ var checkerTests = []struct {
 	got:                  42,
 	args:                 []interface{}{"42"},
 	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: 42\n\t+: \"42\"\n",
+}, {
+	about:                "Equals: different multi-line strings",
+	checker:              qt.Equals,
+	got:                  "these are\nthe voyages\n",
+	args:                 []interface{}{"these are\nthe voyages"},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: \"\"\"\n\t\tthese are\n\t\tthe voyages\n\t\t\"\"\"\n\t+: \"\"\"\n\t\tthese are\n\t\tthe voyages\"\"\"\n",
+}, {
+	about:                "Equals: multi-line strings with carriage returns",
+	checker:              qt.Equals,
+	got:                  "these are\r\nthe voyages",
+	args:                 []interface{}{"these are\nthe voyages"},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: \"these are\\r\\nthe voyages\"\n\t+: \"\"\"\n\t\tthese are\n\t\tthe voyages\"\"\"\n",
+}, {
+	about:                "Equals: multi-line strings with trailing white space",
+	checker:              qt.Equals,
+	got:                  "these are \nthe voyages",
+	args:                 []interface{}{"these are\nthe voyages"},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: \"these are \\nthe voyages\"\n\t+: \"\"\"\n\t\tthese are\n\t\tthe voyages\"\"\"\n",
 }, {
 	about:                "Equals: nil struct",
 	checker:              qt.Equals,
 	got:                  (*struct{})(nil),
 	args:                 []interface{}{nil},
-	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: (*struct {})(nil)\n\t+: <nil>\n",
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: nil (*struct {})\n\t+: <nil>\n",
+}, {
+	about:                "Equals: pointers to different values",
+	checker:              qt.Equals,
+	got:                  newInt(42),
+	args:                 []interface{}{newInt(47)},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: &42\n\t+: &47\n",
+}, {
+	about:                "Equals: pointers to the same value",
+	checker:              qt.Equals,
+	got:                  newInt(42),
+	args:                 []interface{}{newInt(42)},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: &42 at 0x",
+}, {
+	about:                "Equals: pointer and nil pointer",
+	checker:              qt.Equals,
+	got:                  &struct{ Name string }{"bad wolf"},
+	args:                 []interface{}{(*struct{ Name string })(nil)},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: &struct { Name string }{Name:\"bad wolf\"}\n\t+: nil (*struct { Name string })\n",
+}, {
+	about:   "Equals: same times in different locations",
+	checker: qt.Equals,
+	got:     goodTime,
+	args:    []interface{}{otherZoneTime},
+	expectedNegateFailure: "both values equal time.Date(2012, time.March, 28, 0, 0, 0, 0, time.UTC), but should not\n",
+}, {
+	about:                "Equals: different times",
+	checker:              qt.Equals,
+	got:                  goodTime,
+	args:                 []interface{}{goodTime.Add(time.Second)},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: time.Date(2012, time.March, 28, 0, 0, 0, 0, time.UTC)\n\t+: time.Date(2012, time.March, 28, 0, 0, 1, 0, time.UTC)\n",
+}, {
+	about:   "Equals: values with an Equal method",
+	checker: qt.Equals,
+	got:     caseInsensitive("Bad Wolf"),
+	args:    []interface{}{caseInsensitive("bad wolf")},
+	expectedNegateFailure: `both values equal "Bad Wolf", but should not`,
+}, {
+	about:                "Equals: different values with an Equal method",
+	checker:              qt.Equals,
+	got:                  caseInsensitive("bad wolf"),
+	args:                 []interface{}{caseInsensitive("exterminate")},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: \"bad wolf\"\n\t+: \"exterminate\"\n",
+}, {
+	about:   "Equals: nil pointers with an Equal method",
+	checker: qt.Equals,
+	got:     (*pointerEqual)(nil),
+	args:    []interface{}{(*pointerEqual)(nil)},
+	expectedNegateFailure: "both values equal (*quicktest_test.pointerEqual)(nil), but should not\n",
+}, {
+	about:                "Equals: nil pointer with an Equal method and non-nil pointer",
+	checker:              qt.Equals,
+	got:                  (*pointerEqual)(nil),
+	args:                 []interface{}{&pointerEqual{n: 42}},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: nil (*quicktest_test.pointerEqual)\n\t+: &quicktest_test.pointerEqual{n:42}\n",
+}, {
+	about:                "Equals: Equal method not applicable to the expected type",
+	checker:              qt.Equals,
+	got:                  caseInsensitive("bad wolf"),
+	args:                 []interface{}{"bad wolf"},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: \"bad wolf\"\n\t+: \"bad wolf\"\n",
 }, {
 	about:   "Equals: uncomparable types",
 	checker: qt.Equals,
@@ -60,7 +246,53 @@ var checkerTests = []struct {
 	}{
 		Ints: []int{42, 47},
 	}},
-	expectedCheckFailure: "runtime error: comparing uncomparable type",
+	expectedCheckFailure:  "cannot compare uncomparable type struct { Ints []int } with Equals; use DeepEquals or CmpEquals instead\n",
+	expectedNegateFailure: "cannot compare uncomparable type struct { Ints []int } with Equals; use DeepEquals or CmpEquals instead\n",
+}, {
+	about:                 "Equals: uncomparable dynamic types",
+	checker:               qt.Equals,
+	got:                   []int{42},
+	args:                  []interface{}{[]int{42}},
+	expectedCheckFailure:  "cannot compare uncomparable type []int with Equals; use DeepEquals or CmpEquals instead\n",
+	expectedNegateFailure: "cannot compare uncomparable type []int with Equals; use DeepEquals or CmpEquals instead\n",
+}, {
+	about:   "EqualsDeep: same values",
+	checker: qt.EqualsDeep,
+	got:     42,
+	args:    []interface{}{42},
+	expectedNegateFailure: "both values equal 42, but should not\n",
+}, {
+	about:                "EqualsDeep: different values",
+	checker:              qt.EqualsDeep,
+	got:                  "42",
+	args:                 []interface{}{"47"},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: \"42\"\n\t+: \"47\"\n",
+}, {
+	about:   "EqualsDeep: uncomparable types",
+	checker: qt.EqualsDeep,
+	got: struct {
+		Ints []int
+	}{
+		Ints: []int{42, 47},
+	},
+	args: []interface{}{struct {
+		Ints []int
+	}{
+		Ints: []int{42, 47},
+	}},
+	expectedNegateFailure: "both values equal struct { Ints []int }{Ints:[]int{42, 47}}, but should not\n",
+}, {
+	about:                "EqualsDeep: different uncomparable values",
+	checker:              qt.EqualsDeep,
+	got:                  []string{"bad", "wolf"},
+	args:                 []interface{}{[]string{"bad", "fox"}},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: []string{\"bad\", \"wolf\"}\n\t+: []string{\"bad\", \"fox\"}\n",
+}, {
+	about:   "EqualsDeep: Equal method",
+	checker: qt.EqualsDeep,
+	got:     caseInsensitive("Bad Wolf"),
+	args:    []interface{}{caseInsensitive("bad wolf")},
+	expectedNegateFailure: "both values equal \"Bad Wolf\", but should not\n",
 }, {
 	about:                 "Equals: not enough arguments",
 	checker:               qt.Equals,
@@ -107,7 +339,7 @@ var checkerTests = []struct {
 		Strings: []interface{}{"who", "dalek"},
 		Ints:    []int{42},
 	}},
-	expectedCheckFailure: "values are not equal:\n(-got +want)\nroot.Ints:\n\t-: []int{42, 47}\n\t+: []int{42}\n",
+	expectedCheckFailure: "values are not equal (1 difference found):\n(-got +want)\n  struct{ Strings []any; Ints []int }{\n  \tStrings: {string(\"who\"), string(\"dalek\")},\n  \tInts: []int{\n  \t\t42,\n- \t\t47,\n  \t},\n  }\n",
 }, {
 	about:   "CmpEquals: same values with options",
 	checker: qt.CmpEquals(sameInts),
@@ -123,7 +355,7 @@ var checkerTests = []struct {
 	args: []interface{}{
 		[]int{3, 2, 1},
 	},
-	expectedCheckFailure: "values are not equal:\n(-got +want)\nSort({[]int})[2]:\n\t-: 4\n\t+: 3\n",
+	expectedCheckFailure: "values are not equal (1 difference found):\n(-got +want)\n  []int(Inverse(cmpopts.SortSlices, []int{\n  \t1,\n  \t2,\n- \t4,\n+ \t3,\n  }))\n",
 }, {
 	about:   "CmpEquals: structs with unexported fields not allowed",
 	checker: qt.CmpEquals(),
@@ -135,7 +367,7 @@ var checkerTests = []struct {
 			answer: 42,
 		},
 	},
-	expectedCheckFailure: "cannot handle unexported field: root.answer\nconsider using AllowUnexported or cmpopts.IgnoreUnexported\n",
+	expectedCheckFailure: "cannot handle unexported field at root.answer:\n\t\"github.com/frankban/quicktest_test\".(struct { answer int })\nconsider using a custom Comparer; if you control the implementation of type, you can also consider using an Exporter, AllowUnexported, or cmpopts.IgnoreUnexported\n",
 }, {
 	about:   "CmpEquals: structs with unexported fields ignored",
 	checker: qt.CmpEquals(cmpopts.IgnoreUnexported(struct{ answer int }{})),
@@ -160,6 +392,45 @@ var checkerTests = []struct {
 	args:                  []interface{}{[]int{42}, "bad wolf"},
 	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected bad wolf\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected bad wolf\n",
+}, {
+	about:   "CmpEquals: same values with call options",
+	checker: qt.DeepEquals,
+	got:     []int{1, 2, 3},
+	args:    []interface{}{[]int{3, 2, 1}, sameInts},
+	expectedNegateFailure: "both values deeply equal []int{1, 2, 3}, but should not",
+}, {
+	about: "CmpEquals: call options added to stored options",
+	checker: qt.CmpEquals(cmpopts.IgnoreUnexported(struct {
+		answer int
+		Ints   []int
+	}{})),
+	got: struct {
+		answer int
+		Ints   []int
+	}{
+		answer: 42,
+	},
+	args: []interface{}{struct {
+		answer int
+		Ints   []int
+	}{
+		answer: 47,
+		Ints:   []int{},
+	}, cmpopts.EquateEmpty()},
+	expectedNegateFailure: "both values deeply equal struct { answer int; Ints []int }",
+}, {
+	about:                "CmpEquals: different values with call options",
+	checker:              qt.DeepEquals,
+	got:                  []int{1, 2, 4},
+	args:                 []interface{}{[]int{3, 2, 1}, sameInts},
+	expectedCheckFailure: "values are not equal (1 difference found):\n(-got +want)\n  []int(Inverse(cmpopts.SortSlices, []int{\n  \t1,\n  \t2,\n- \t4,\n+ \t3,\n  }))\n",
+}, {
+	about:                 "CmpEquals: too many arguments after call options",
+	checker:               qt.DeepEquals,
+	got:                   []int{1},
+	args:                  []interface{}{[]int{1}, sameInts, 42},
+	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected 42\n",
+	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected 42\n",
 }, {
 	about:   "DeepEquals: same values",
 	checker: qt.DeepEquals,
@@ -175,7 +446,7 @@ var checkerTests = []struct {
 	args: []interface{}{
 		[]int{3, 2, 1},
 	},
-	expectedCheckFailure: "values are not equal:\n(-got +want)\n{[]int}:\n\t-: []int{1, 2, 3}\n\t+: []int{3, 2, 1}\n",
+	expectedCheckFailure: "values are not equal (2 differences found):\n(-got +want)\n  []int{\n- \t1, 2, 3,\n+ \t3, 2, 1,\n  }\n",
 }, {
 	about:                 "DeepEquals: not enough arguments",
 	checker:               qt.DeepEquals,
@@ -187,6 +458,708 @@ var checkerTests = []struct {
 	args:                  []interface{}{nil, nil},
 	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected <nil>\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected <nil>\n",
+}, {
+	about:   "CmpEqualsWithComparer: same values",
+	checker: qt.CmpEqualsWithComparer(bigIntEquals),
+	got:     []*big.Int{big.NewInt(42), new(big.Int).SetBytes([]byte{47})},
+	args: []interface{}{
+		[]*big.Int{big.NewInt(42), big.NewInt(47)},
+	},
+	expectedNegateFailure: "both values deeply equal []*big.Int{",
+}, {
+	about:   "CmpEqualsWithComparer: different values",
+	checker: qt.CmpEqualsWithComparer(bigIntEquals),
+	got:     []*big.Int{big.NewInt(42)},
+	args: []interface{}{
+		[]*big.Int{big.NewInt(47)},
+	},
+	expectedCheckFailure: "values are not equal (1 difference found):\n(-got +want)\n",
+}, {
+	about:   "CmpEqualsWithComparer: call options",
+	checker: qt.CmpEqualsWithComparer(bigIntEquals),
+	got:     []*big.Int{big.NewInt(47), big.NewInt(42)},
+	args: []interface{}{
+		[]*big.Int{big.NewInt(42), big.NewInt(47)},
+		cmpopts.SortSlices(func(x, y *big.Int) bool {
+			return x.Cmp(y) < 0
+		}),
+	},
+	expectedNegateFailure: "both values deeply equal []*big.Int{",
+}, {
+	about:                 "CmpEqualsWithComparer: not a function",
+	checker:               qt.CmpEqualsWithComparer(42),
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "invalid comparer: expected a func(x, y T) bool, got int instead\n",
+	expectedNegateFailure: "invalid comparer: expected a func(x, y T) bool, got int instead\n",
+}, {
+	about:                 "CmpEqualsWithComparer: wrong signature",
+	checker:               qt.CmpEqualsWithComparer(func(x *big.Int) bool { return false }),
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "invalid comparer: expected a func(x, y T) bool, got func(*big.Int) bool instead\n",
+	expectedNegateFailure: "invalid comparer: expected a func(x, y T) bool, got func(*big.Int) bool instead\n",
+}, {
+	about:                 "CmpEqualsWithComparer: named bool result",
+	checker:               qt.CmpEqualsWithComparer(func(x, y *big.Int) namedBool { return false }),
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "invalid comparer: expected a func(x, y T) bool, got func(*big.Int, *big.Int) quicktest_test.namedBool instead\n",
+	expectedNegateFailure: "invalid comparer: expected a func(x, y T) bool, got func(*big.Int, *big.Int) quicktest_test.namedBool instead\n",
+}, {
+	about:                 "CmpEqualsWithComparer: nil function",
+	checker:               qt.CmpEqualsWithComparer((func(x, y *big.Int) bool)(nil)),
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "invalid comparer: nil func(*big.Int, *big.Int) bool function\n",
+	expectedNegateFailure: "invalid comparer: nil func(*big.Int, *big.Int) bool function\n",
+}, {
+	about:                 "CmpEqualsWithComparer: different argument types",
+	checker:               qt.CmpEqualsWithComparer(func(x *big.Int, y int) bool { return false }),
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "invalid comparer: arguments must be of the same type, got *big.Int and int\n",
+	expectedNegateFailure: "invalid comparer: arguments must be of the same type, got *big.Int and int\n",
+}, {
+	about:   "DeepEqualsFirstDiff: same values",
+	checker: qt.DeepEqualsFirstDiff,
+	got:     []int{1, 2, 3},
+	args: []interface{}{
+		[]int{1, 2, 3},
+	},
+	expectedNegateFailure: "both values deeply equal []int{1, 2, 3}, but should not",
+}, {
+	about:   "DeepEqualsFirstDiff: different values",
+	checker: qt.DeepEqualsFirstDiff,
+	got:     []int{1, 2, 3},
+	args: []interface{}{
+		[]int{3, 2, 1},
+	},
+	expectedCheckFailure: "values are not equal (2 differences found), first difference at {[]int}[0]:\n(-got +want)\n\t-: 1\n\t+: 3\n",
+}, {
+	about:   "DeepEqualsFirstDiff: missing element",
+	checker: qt.DeepEqualsFirstDiff,
+	got:     map[string]int{"a": 1},
+	args: []interface{}{
+		map[string]int{"a": 1, "b": 2},
+	},
+	expectedCheckFailure: "values are not equal (1 difference found), first difference at {map[string]int}[\"b\"]:\n(-got +want)\n\t-: <missing>\n\t+: 2\n",
+}, {
+	about:   "DeepEqualsFirstDiff: with compare options",
+	checker: qt.DeepEqualsFirstDiff,
+	got:     []int{1, 2, 3},
+	args:    []interface{}{[]int{3, 2, 1}, sameInts},
+	expectedNegateFailure: "both values deeply equal []int{1, 2, 3}, but should not",
+}, {
+	about:                 "DeepEqualsFirstDiff: not enough arguments",
+	checker:               qt.DeepEqualsFirstDiff,
+	expectedCheckFailure:  "not enough arguments provided to checker: got 0, want 1\n",
+	expectedNegateFailure: "not enough arguments provided to checker: got 0, want 1\n",
+}, {
+	about:   "UnorderedEquals: same elements",
+	checker: qt.UnorderedEquals,
+	got:     []string{"who", "dalek", "who"},
+	args:    []interface{}{[3]string{"who", "who", "dalek"}},
+	expectedNegateFailure: "values contain the same elements, but should not:\n(value)\n\t[]string{\"who\", \"dalek\", \"who\"}\n",
+}, {
+	about:   "UnorderedEquals: deeply equal elements",
+	checker: qt.UnorderedEquals,
+	got:     [][]int{{42}, {47, 42}},
+	args:    []interface{}{[][]int{{47, 42}, {42}}},
+	expectedNegateFailure: "values contain the same elements, but should not:\n",
+}, {
+	about:   "UnorderedEquals: empty",
+	checker: qt.UnorderedEquals,
+	got:     []int(nil),
+	args:    []interface{}{[]int{}},
+	expectedNegateFailure: "values contain the same elements, but should not:\n",
+}, {
+	about:                "UnorderedEquals: different multiplicities",
+	checker:              qt.UnorderedEquals,
+	got:                  []string{"who", "dalek", "dalek"},
+	args:                 []interface{}{[]string{"who", "who", "dalek"}},
+	expectedCheckFailure: "values do not contain the same elements:\n(missing)\n\t\"who\"\n(extra)\n\t\"dalek\"\n",
+}, {
+	about:                "UnorderedEquals: missing elements",
+	checker:              qt.UnorderedEquals,
+	got:                  []int{42},
+	args:                 []interface{}{[]int{47, 42, 1}},
+	expectedCheckFailure: "values do not contain the same elements:\n(missing)\n\t47\n\t1\n",
+}, {
+	about:                "UnorderedEquals: extra elements",
+	checker:              qt.UnorderedEquals,
+	got:                  []int{42, 47},
+	args:                 []interface{}{[]int{}},
+	expectedCheckFailure: "values do not contain the same elements:\n(extra)\n\t42\n\t47\n",
+}, {
+	about:                 "UnorderedEquals: not a slice",
+	checker:               qt.UnorderedEquals,
+	got:                   "bad wolf",
+	args:                  []interface{}{[]string{"bad wolf"}},
+	expectedCheckFailure:  "expected a slice or an array, got string instead\n",
+	expectedNegateFailure: "expected a slice or an array, got string instead\n",
+}, {
+	about:                 "UnorderedEquals: expected value not a slice",
+	checker:               qt.UnorderedEquals,
+	got:                   []int{},
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "expected value is of type <nil>, not a slice or an array\n",
+	expectedNegateFailure: "expected value is of type <nil>, not a slice or an array\n",
+}, {
+	about:                 "MatchesPartial: matching fields",
+	checker:               qt.MatchesPartial(companion{Name: "Rose", Tags: []string{"human"}}),
+	got:                   companion{Name: "Rose", Age: 19, Tags: []string{"human"}, secret: "bad wolf"},
+	expectedNegateFailure: "values are equal in all the specified fields, but should not:\n(fields)\n\tName, Tags\n",
+}, {
+	about:                 "MatchesPartial: pointers",
+	checker:               qt.MatchesPartial(&companion{Age: 19}),
+	got:                   &companion{Name: "Rose", Age: 19},
+	expectedNegateFailure: "values are equal in all the specified fields, but should not:\n(fields)\n\tAge\n",
+}, {
+	about:                 "MatchesPartial: unexported fields ignored",
+	checker:               qt.MatchesPartial(companion{Name: "Rose", secret: "bad wolf"}),
+	got:                   companion{Name: "Rose"},
+	expectedNegateFailure: "values are equal in all the specified fields, but should not:\n(fields)\n\tName\n",
+}, {
+	about:                "MatchesPartial: different field",
+	checker:              qt.MatchesPartial(companion{Name: "Rose", Age: 20}),
+	got:                  companion{Name: "Rose", Age: 19},
+	expectedCheckFailure: "values are not equal in field Age:\n(-got +want)\n\t-: 19\n\t+: 20\n",
+}, {
+	about:                "MatchesPartial: different slice field",
+	checker:              qt.MatchesPartial(companion{Tags: []string{"human"}}),
+	got:                  companion{Name: "Rose"},
+	expectedCheckFailure: "values are not equal in field Tags:\n(-got +want)\n\t-: []string(nil)\n\t+: []string{\"human\"}\n",
+}, {
+	about:                 "MatchesPartial: different types",
+	checker:               qt.MatchesPartial(companion{Name: "Rose"}),
+	got:                   struct{ Name string }{"Rose"},
+	expectedCheckFailure:  "cannot compare struct { Name string } with quicktest_test.companion\n",
+	expectedNegateFailure: "cannot compare struct { Name string } with quicktest_test.companion\n",
+}, {
+	about:                 "MatchesPartial: not a struct",
+	checker:               qt.MatchesPartial(companion{Name: "Rose"}),
+	got:                   "Rose",
+	expectedCheckFailure:  "expected a struct or a pointer to struct, got string instead\n",
+	expectedNegateFailure: "expected a struct or a pointer to struct, got string instead\n",
+}, {
+	about:                 "MatchesPartial: nil pointer",
+	checker:               qt.MatchesPartial(companion{Name: "Rose"}),
+	got:                   (*companion)(nil),
+	expectedCheckFailure:  "expected a struct or a pointer to struct, got *quicktest_test.companion instead\n",
+	expectedNegateFailure: "expected a struct or a pointer to struct, got *quicktest_test.companion instead\n",
+}, {
+	about:                 "MatchesPartial: expected value not a struct",
+	checker:               qt.MatchesPartial(42),
+	got:                   companion{},
+	expectedCheckFailure:  "expected value must be a struct or a pointer to struct, got int instead\n",
+	expectedNegateFailure: "expected value must be a struct or a pointer to struct, got int instead\n",
+}, {
+	about:   "MatchesPartial: fields with unexported fields",
+	checker: qt.MatchesPartial(crew{Captain: &companion{Name: "Rose", secret: "bad wolf"}}),
+	got:     crew{Captain: &companion{Name: "Rose", secret: "bad wolf"}, Size: 2},
+	expectedNegateFailure: "values are equal in all the specified fields, but should not:\n(fields)\n\tCaptain\n",
+}, {
+	about:                 "FieldEquals: equal field",
+	checker:               qt.FieldEquals("Name", "Rose"),
+	got:                   companion{Name: "Rose", Age: 19},
+	expectedNegateFailure: "field Name equals \"Rose\", but should not\n",
+}, {
+	about:                 "FieldEquals: nested fields",
+	checker:               qt.FieldEquals("Captain.Tags", []string{"time lord"}),
+	got:                   &crew{Captain: &companion{Tags: []string{"time lord"}}},
+	expectedNegateFailure: "field Captain.Tags equals []string{\"time lord\"}, but should not\n",
+}, {
+	about:   "FieldEquals: nested struct field",
+	checker: qt.FieldEquals("Ship.Name", "TARDIS"),
+	got: func() crew {
+		var c crew
+		c.Ship.Name = "TARDIS"
+		return c
+	}(),
+	expectedNegateFailure: "field Ship.Name equals \"TARDIS\", but should not\n",
+}, {
+	about:                "FieldEquals: different field",
+	checker:              qt.FieldEquals("Captain.Age", 20),
+	got:                  crew{Captain: &companion{Age: 19}},
+	expectedCheckFailure: "values are not equal in field Captain.Age:\n(-got +want)\n\t-: 19\n\t+: 20\n",
+}, {
+	about:                "FieldEquals: nil pointer in path",
+	checker:              qt.FieldEquals("Captain.Name", "Doctor"),
+	got:                  crew{},
+	expectedCheckFailure: "cannot get field Captain.Name: Captain is nil\n",
+}, {
+	about:                 "FieldEquals: field not found",
+	checker:               qt.FieldEquals("Ship.Captain", "Doctor"),
+	got:                   crew{},
+	expectedCheckFailure:  "field Captain not found in struct { Name string }\n",
+	expectedNegateFailure: "field Captain not found in struct { Name string }\n",
+}, {
+	about:                 "FieldEquals: unexported field",
+	checker:               qt.FieldEquals("secret", ""),
+	got:                   companion{},
+	expectedCheckFailure:  "field secret of quicktest_test.companion is not exported\n",
+	expectedNegateFailure: "field secret of quicktest_test.companion is not exported\n",
+}, {
+	about:                 "FieldEquals: not a struct in path",
+	checker:               qt.FieldEquals("Size.Value", 1),
+	got:                   crew{},
+	expectedCheckFailure:  "cannot get field Size.Value: Size is of type int, not a struct\n",
+	expectedNegateFailure: "cannot get field Size.Value: Size is of type int, not a struct\n",
+}, {
+	about:                 "FieldEquals: not a struct",
+	checker:               qt.FieldEquals("Name", "Rose"),
+	got:                   "Rose",
+	expectedCheckFailure:  "expected a struct or a pointer to struct, got string instead\n",
+	expectedNegateFailure: "expected a struct or a pointer to struct, got string instead\n",
+}, {
+	about:   "FieldEquals: field with unexported fields",
+	checker: qt.FieldEquals("Captain", &companion{Name: "Rose", secret: "bad wolf"}),
+	got:     crew{Captain: &companion{Name: "Rose", secret: "bad wolf"}},
+	expectedNegateFailure: "field Captain equals &quicktest_test.companion{Name:\"Rose\", Age:0, Tags:[]string(nil), secret:\"bad wolf\"}, but should not\n",
+}, {
+	about:   "DeepEqualsApprox: values within margin",
+	checker: qt.DeepEqualsApprox(0, 0.01),
+	got: []struct {
+		Name  string
+		Value float64
+	}{{"pi", 3.141}},
+	args: []interface{}{[]struct {
+		Name  string
+		Value float64
+	}{{"pi", 3.14}}},
+	expectedNegateFailure: "both values deeply equal []struct { Name string; Value float64 }",
+}, {
+	about:   "DeepEqualsApprox: values within fraction",
+	checker: qt.DeepEqualsApprox(0.1, 0),
+	got:     map[string]float32{"answer": 42},
+	args:    []interface{}{map[string]float32{"answer": 45}},
+	expectedNegateFailure: "both values deeply equal map[string]float32{\"answer\":42}, but should not",
+}, {
+	about:                "DeepEqualsApprox: values out of tolerance",
+	checker:              qt.DeepEqualsApprox(0, 0.01),
+	got:                  []float64{1, 2, 3.1},
+	args:                 []interface{}{[]float64{1, 2, 3}},
+	expectedCheckFailure: "values are not equal (1 difference found):\n(-got +want)\n  []float64{\n  \t1,\n  \t2,\n- \t3.1,\n+ \t3,\n  }\n",
+}, {
+	about:   "DeepEqualsApprox: different non float values",
+	checker: qt.DeepEqualsApprox(0.5, 0.5),
+	got: struct {
+		Name  string
+		Value float64
+	}{"pi", 3.14},
+	args: []interface{}{struct {
+		Name  string
+		Value float64
+	}{"e", 3.14}},
+	expectedCheckFailure: "values are not equal (1 difference found):\n(-got +want)\n  struct{ Name string; Value float64 }{\n- \tName:  \"pi\",\n+ \tName:  \"e\",\n  \tValue: 3.14,\n  }\n",
+}, {
+	about:   "FloatsClose: close values",
+	checker: qt.FloatsClose([]float64{0.5, 1.5}, 0.01),
+	got:     []float64{0.501, 1.499},
+	expectedNegateFailure: "slices are close within a tolerance of 0.01, but should not:\n(-got +want)\n\t-: [0.501 1.499]\n\t+: [0.5 1.5]\n",
+}, {
+	about:   "FloatsClose: NaN values",
+	checker: qt.FloatsClose([]float64{math.NaN()}, 0),
+	got:     []float64{math.NaN()},
+	expectedNegateFailure: "slices are close within a tolerance of 0, but should not:\n(-got +want)\n\t-: [NaN]\n\t+: [NaN]\n",
+}, {
+	about:   "FloatsClose: empty slices",
+	checker: qt.FloatsClose(nil, 0),
+	got:     []float64{},
+	expectedNegateFailure: "slices are close within a tolerance of 0, but should not:\n(-got +want)\n\t-: []\n\t+: []\n",
+}, {
+	about:                "FloatsClose: value exceeding tolerance",
+	checker:              qt.FloatsClose([]float64{0.5, 1.5, 2.5}, 0.01),
+	got:                  []float64{0.5, 1.25, 3},
+	expectedCheckFailure: "slices are not close within a tolerance of 0.01, first difference at index 1:\n(-got +want)\n\t-: 1.25\n\t+: 1.5\n(difference)\n\t0.25\n",
+}, {
+	about:                "FloatsClose: NaN value",
+	checker:              qt.FloatsClose([]float64{1}, 1),
+	got:                  []float64{math.NaN()},
+	expectedCheckFailure: "slices are not close within a tolerance of 1, first difference at index 0:\n(-got +want)\n\t-: NaN\n\t+: 1\n(difference)\n\tNaN\n",
+}, {
+	about:                "FloatsClose: different lengths",
+	checker:              qt.FloatsClose([]float64{1, 2}, 0),
+	got:                  []float64{1},
+	expectedCheckFailure: "slices have different lengths:\n(-got length +want length)\n\t-: 1\n\t+: 2\n(-got +want)\n\t-: [1]\n\t+: [1 2]\n",
+}, {
+	about:                 "FloatsClose: not a float slice",
+	checker:               qt.FloatsClose([]float64{1}, 0),
+	got:                   []float32{1},
+	expectedCheckFailure:  "expected a []float64, got []float32 instead\n",
+	expectedNegateFailure: "expected a []float64, got []float32 instead\n",
+}, {
+	about:                 "FloatsClose: invalid tolerance",
+	checker:               qt.FloatsClose([]float64{1}, -1),
+	got:                   []float64{1},
+	expectedCheckFailure:  "invalid tolerance -1\n",
+	expectedNegateFailure: "invalid tolerance -1\n",
+}, {
+	about:   "WithinPercent: value within band",
+	checker: qt.WithinPercent(200, 10),
+	got:     215,
+	expectedNegateFailure: "value is within 10% of 200, but should not:\n(-got +want)\n\t-: 215\n\t+: 200\n(allowed range)\n\t[180, 220]\n(deviation)\n\t7.5%\n",
+}, {
+	about:   "WithinPercent: value on band limit",
+	checker: qt.WithinPercent(-200, 10),
+	got:     float32(-180),
+	expectedNegateFailure: "value is within 10% of -200, but should not:\n(-got +want)\n\t-: -180\n\t+: -200\n(allowed range)\n\t[-220, -180]\n(deviation)\n\t10%\n",
+}, {
+	about:   "WithinPercent: zero values",
+	checker: qt.WithinPercent(0, 10),
+	got:     uint8(0),
+	expectedNegateFailure: "value is within 10% of 0, but should not:\n(-got +want)\n\t-: 0\n\t+: 0\n(allowed range)\n\t[0, 0]\n(deviation)\n\t0%\n",
+}, {
+	about:                "WithinPercent: value out of band",
+	checker:              qt.WithinPercent(0.8, 5),
+	got:                  0.7,
+	expectedCheckFailure: "value is not within 5% of 0.8:\n(-got +want)\n\t-: 0.7\n\t+: 0.8\n(allowed range)\n\t[0.76, 0.8400000000000001]\n(deviation)\n\t12.5%\n",
+}, {
+	about:                "WithinPercent: zero expected value",
+	checker:              qt.WithinPercent(0, 10),
+	got:                  1,
+	expectedCheckFailure: "value is not within 10% of 0:\n(-got +want)\n\t-: 1\n\t+: 0\n(allowed range)\n\t[0, 0]\n(deviation)\n\tinfinite (expected value is zero)\n",
+}, {
+	about:                "WithinPercent: NaN value",
+	checker:              qt.WithinPercent(1, 10),
+	got:                  math.NaN(),
+	expectedCheckFailure: "value is not within 10% of 1:\n(value)\n\tNaN\n",
+}, {
+	about:                 "WithinPercent: not a number",
+	checker:               qt.WithinPercent(1, 10),
+	got:                   "1",
+	expectedCheckFailure:  "expected a numeric value, got string instead\n",
+	expectedNegateFailure: "expected a numeric value, got string instead\n",
+}, {
+	about:                 "WithinPercent: invalid percentage",
+	checker:               qt.WithinPercent(1, -10),
+	got:                   1,
+	expectedCheckFailure:  "invalid percentage band -10% around 1\n",
+	expectedNegateFailure: "invalid percentage band -10% around 1\n",
+}, {
+	about:   "ComplexClose: within tolerance",
+	checker: qt.ComplexClose(complex(1, 2), 0.5),
+	got:     complex(1.3, 2.4),
+	expectedNegateFailure: "complex numbers are within 0.5 of each other, but should not:\n(-got +want)\n\t-: (1.3+2.4i)\n\t+: (1+2i)\n(|got-want|)\n\t0.5",
+}, {
+	about:   "ComplexClose: complex64",
+	checker: qt.ComplexClose(complex(0, -4), 0),
+	got:     complex64(complex(0, -4)),
+	expectedNegateFailure: "complex numbers are within 0 of each other, but should not:\n(-got +want)\n\t-: (0-4i)\n\t+: (0-4i)\n(|got-want|)\n\t0\n",
+}, {
+	about:                "ComplexClose: outside tolerance",
+	checker:              qt.ComplexClose(complex(1, 2), 0.1),
+	got:                  complex(4, 6),
+	expectedCheckFailure: "complex numbers are not within 0.1 of each other:\n(-got +want)\n\t-: (4+6i)\n\t+: (1+2i)\n(|got-want|)\n\t5\n",
+}, {
+	about:                "ComplexClose: NaN",
+	checker:              qt.ComplexClose(complex(1, 2), 0.1),
+	got:                  complex(math.NaN(), 2),
+	expectedCheckFailure: "complex numbers are not within 0.1 of each other:\n(-got +want)\n\t-: (NaN+2i)\n\t+: (1+2i)\n(|got-want|)\n\tNaN\n",
+}, {
+	about:                 "ComplexClose: not a complex number",
+	checker:               qt.ComplexClose(complex(1, 2), 0.1),
+	got:                   1.0,
+	expectedCheckFailure:  "expected a complex number, got float64 instead\n",
+	expectedNegateFailure: "expected a complex number, got float64 instead\n",
+}, {
+	about:                 "ComplexClose: invalid tolerance",
+	checker:               qt.ComplexClose(complex(1, 2), -1),
+	got:                   complex(1, 2),
+	expectedCheckFailure:  "invalid tolerance -1\n",
+	expectedNegateFailure: "invalid tolerance -1\n",
+}, {
+	about:   "TimeEquals: same times",
+	checker: qt.TimeEquals(goodTime, 0),
+	got:     otherZoneTime,
+	expectedNegateFailure: "times are equal within a tolerance of 0s, but should not:\n(got)\n\t2012-03-28T01:00:00+01:00\n(want)\n\t2012-03-28T00:00:00Z\n(difference)\n\t0s\n",
+}, {
+	about:   "TimeEquals: times within tolerance",
+	checker: qt.TimeEquals(goodTime, time.Second),
+	got:     goodTime.Add(-500 * time.Millisecond),
+	expectedNegateFailure: "times are equal within a tolerance of 1s, but should not:\n(got)\n\t2012-03-27T23:59:59.5Z\n(want)\n\t2012-03-28T00:00:00Z\n(difference)\n\t500ms\n",
+}, {
+	about:                "TimeEquals: times out of tolerance",
+	checker:              qt.TimeEquals(goodTime, time.Second),
+	got:                  goodTime.Add(1500 * time.Millisecond),
+	expectedCheckFailure: "times are not equal within a tolerance of 1s:\n(-got +want)\n\t-: 2012-03-28T00:00:01.5Z\n\t+: 2012-03-28T00:00:00Z\n(difference)\n\t1.5s\n",
+}, {
+	about:                 "TimeEquals: not a time",
+	checker:               qt.TimeEquals(goodTime, time.Second),
+	got:                   "2012-03-28",
+	expectedCheckFailure:  "expected a time.Time, got string instead\n",
+	expectedNegateFailure: "expected a time.Time, got string instead\n",
+}, {
+	about:                 "TimeEquals: too many arguments",
+	checker:               qt.TimeEquals(goodTime, time.Second),
+	got:                   goodTime,
+	args:                  []interface{}{goodTime},
+	expectedCheckFailure:  "too many arguments provided to checker: got 1, want 0: unexpected 2012-03-28 00:00:00 +0000 UTC\n",
+	expectedNegateFailure: "too many arguments provided to checker: got 1, want 0: unexpected 2012-03-28 00:00:00 +0000 UTC\n",
+}, {
+	about:   "TimeEqualsRounded: same times",
+	checker: qt.TimeEqualsRounded(goodTime, time.Second),
+	got:     otherZoneTime,
+	expectedNegateFailure: "times are equal when rounded to 1s, but should not:\n(rounded)\n\t2012-03-28T01:00:00+01:00\n(original got)\n\t2012-03-28T01:00:00+01:00\n(original want)\n\t2012-03-28T00:00:00Z\n",
+}, {
+	about:   "TimeEqualsRounded: times equal when rounded",
+	checker: qt.TimeEqualsRounded(goodTime.Add(300*time.Microsecond), time.Millisecond),
+	got:     goodTime.Add(-400 * time.Microsecond),
+	expectedNegateFailure: "times are equal when rounded to 1ms, but should not:\n(rounded)\n\t2012-03-28T00:00:00Z\n(original got)\n\t2012-03-27T23:59:59.9996Z\n(original want)\n\t2012-03-28T00:00:00.0003Z\n",
+}, {
+	about:                "TimeEqualsRounded: close times rounded differently",
+	checker:              qt.TimeEqualsRounded(goodTime.Add(400*time.Millisecond), time.Second),
+	got:                  goodTime.Add(600 * time.Millisecond),
+	expectedCheckFailure: "times are not equal when rounded to 1s:\n(-got +want)\n\t-: 2012-03-28T00:00:01Z\n\t+: 2012-03-28T00:00:00Z\n(original got)\n\t2012-03-28T00:00:00.6Z\n(original want)\n\t2012-03-28T00:00:00.4Z\n",
+}, {
+	about:                 "TimeEqualsRounded: not a time",
+	checker:               qt.TimeEqualsRounded(goodTime, time.Second),
+	got:                   "2012-03-28",
+	expectedCheckFailure:  "expected a time.Time, got string instead\n",
+	expectedNegateFailure: "expected a time.Time, got string instead\n",
+}, {
+	about:                 "TimeEqualsRounded: invalid unit",
+	checker:               qt.TimeEqualsRounded(goodTime, 0),
+	got:                   goodTime,
+	expectedCheckFailure:  "invalid rounding unit 0s\n",
+	expectedNegateFailure: "invalid rounding unit 0s\n",
+}, {
+	about:   "DurationEquals: same durations",
+	checker: qt.DurationEquals,
+	got:     1500 * time.Millisecond,
+	args:    []interface{}{time.Duration(1.5 * float64(time.Second))},
+	expectedNegateFailure: "both durations equal 1.5s, but should not\n",
+}, {
+	about:                "DurationEquals: different durations",
+	checker:              qt.DurationEquals,
+	got:                  1500 * time.Millisecond,
+	args:                 []interface{}{2 * time.Second},
+	expectedCheckFailure: "durations are not equal:\n(-got +want)\n\t-: 1.5s\n\t+: 2s\n(difference)\n\t500ms\n",
+}, {
+	about:                 "DurationEquals: not a duration",
+	checker:               qt.DurationEquals,
+	got:                   int64(42),
+	args:                  []interface{}{time.Second},
+	expectedCheckFailure:  "expected a time.Duration, got int64 instead\n",
+	expectedNegateFailure: "expected a time.Duration, got int64 instead\n",
+}, {
+	about:                 "DurationEquals: expected value not a duration",
+	checker:               qt.DurationEquals,
+	got:                   time.Second,
+	args:                  []interface{}{1000},
+	expectedCheckFailure:  "expected value is of type int, not time.Duration\n",
+	expectedNegateFailure: "expected value is of type int, not time.Duration\n",
+}, {
+	about:   "DurationEqualsApprox: within tolerance",
+	checker: qt.DurationEqualsApprox(10 * time.Millisecond),
+	got:     995 * time.Millisecond,
+	args:    []interface{}{time.Second},
+	expectedNegateFailure: "durations are equal within a tolerance of 10ms, but should not:\n(got)\n\t995ms\n(want)\n\t1s\n(difference)\n\t5ms\n",
+}, {
+	about:                "DurationEqualsApprox: outside tolerance",
+	checker:              qt.DurationEqualsApprox(10 * time.Millisecond),
+	got:                  1020 * time.Millisecond,
+	args:                 []interface{}{time.Second},
+	expectedCheckFailure: "durations are not equal within a tolerance of 10ms:\n(-got +want)\n\t-: 1.02s\n\t+: 1s\n(difference)\n\t20ms\n",
+}, {
+	about:                 "DurationEqualsApprox: not enough arguments",
+	checker:               qt.DurationEqualsApprox(time.Millisecond),
+	expectedCheckFailure:  "not enough arguments provided to checker: got 0, want 1\n",
+	expectedNegateFailure: "not enough arguments provided to checker: got 0, want 1\n",
+}, {
+	about:   "BytesEquals: same values",
+	checker: qt.BytesEquals,
+	got:     []byte("bad wolf"),
+	args:    []interface{}{[]byte("bad wolf")},
+	expectedNegateFailure: "byte slices are equal, but should not:\n(value)\n\t00000000  62 61 64 20 77 6f 6c 66                           |bad wolf|\n",
+}, {
+	about:   "BytesEquals: nil and empty",
+	checker: qt.BytesEquals,
+	got:     []byte(nil),
+	args:    []interface{}{[]byte{}},
+	expectedNegateFailure: "byte slices are equal, but should not:\n(value)\n\n",
+}, {
+	about:   "BytesEquals: different values",
+	checker: qt.BytesEquals,
+	got:     []byte("these are the voyages\x00"),
+	args:    []interface{}{[]byte("these are the voyages\x01")},
+	expectedCheckFailure: "byte slices are not equal: first difference at offset 21 (0x15):\n(-got +want)\n" +
+		"\t : 00000000  74 68 65 73 65 20 61 72  65 20 74 68 65 20 76 6f  |these are the vo|\n" +
+		"\t-: 00000010  79 61 67 65 73 00                                 |yages.|\n" +
+		"\t+: 00000010  79 61 67 65 73 01                                 |yages.|\n" +
+		"\t                            ^^\n",
+}, {
+	about:   "BytesEquals: different lengths",
+	checker: qt.BytesEquals,
+	got:     []byte{0xca, 0xfe},
+	args:    []interface{}{[]byte{0xca}},
+	expectedCheckFailure: "byte slices are not equal: first difference at offset 1 (0x1):\n(-got +want)\n" +
+		"\t-: 00000000  ca fe                                             |..|\n" +
+		"\t+: 00000000  ca                                                |.|\n" +
+		"\t                ^^\n",
+}, {
+	about:                 "BytesEquals: not a byte slice",
+	checker:               qt.BytesEquals,
+	got:                   "bad wolf",
+	args:                  []interface{}{[]byte("bad wolf")},
+	expectedCheckFailure:  "expected a []byte, got string instead\n",
+	expectedNegateFailure: "expected a []byte, got string instead\n",
+}, {
+	about:                 "BytesEquals: expected value not a byte slice",
+	checker:               qt.BytesEquals,
+	got:                   []byte("bad wolf"),
+	args:                  []interface{}{"bad wolf"},
+	expectedCheckFailure:  "expected value is of type string, not []byte\n",
+	expectedNegateFailure: "expected value is of type string, not []byte\n",
+}, {
+	about:   "RoundTrips: value preserved",
+	checker: qt.JSONRoundTrips,
+	got: &struct {
+		Name   string
+		Answer int
+	}{"bad wolf", 42},
+	expectedNegateFailure: "value round trips, but should not:\n(value)\n\t&struct { Name string; Answer int }{Name:\"bad wolf\", Answer:42}\n(encoded)\n\t\"{\\\"Name\\\":\\\"bad wolf\\\",\\\"Answer\\\":42}\"\n",
+}, {
+	about:   "RoundTrips: value not preserved",
+	checker: qt.JSONRoundTrips,
+	got: struct {
+		Name   string
+		Answer int `json:"-"`
+	}{"bad wolf", 42},
+	expectedCheckFailure: "value does not round trip:\n(encoded)\n\t\"{\\\"Name\\\":\\\"bad wolf\\\"}\"\n(-original +decoded)\n",
+}, {
+	about:                "RoundTrips: unexported fields not preserved",
+	checker:              qt.JSONRoundTrips,
+	got:                  companion{Name: "Rose", secret: "bad wolf"},
+	expectedCheckFailure: "value does not round trip:\n(encoded)\n\t\"{\\\"Name\\\":\\\"Rose\\\",\\\"Age\\\":0,\\\"Tags\\\":null}\"\n(original)\n\tquicktest_test.companion{Name:\"Rose\", Age:0, Tags:[]string(nil), secret:\"bad wolf\"}\n(decoded)\n\tquicktest_test.companion{Name:\"Rose\", Age:0, Tags:[]string(nil), secret:\"\"}\n",
+}, {
+	about:                "RoundTrips: marshal error",
+	checker:              qt.JSONRoundTrips,
+	got:                  map[string]interface{}{"f": func() {}},
+	expectedCheckFailure: "cannot marshal value: json: unsupported type: func()\n",
+}, {
+	about: "RoundTrips: unmarshal error",
+	checker: qt.RoundTrips(json.Marshal, func([]byte, interface{}) error {
+		return errors.New("bad wolf")
+	}),
+	got:                  42,
+	expectedCheckFailure: "cannot unmarshal value: bad wolf\n(value)\n\t42\n(encoded)\n\t\"42\"\n",
+}, {
+	about:                 "RoundTrips: nil value",
+	checker:               qt.JSONRoundTrips,
+	got:                   nil,
+	expectedCheckFailure:  "cannot round trip a nil value\n",
+	expectedNegateFailure: "cannot round trip a nil value\n",
+}, {
+	about:   "SameBehavior: same results",
+	checker: qt.SameBehavior(strings.ToUpper, []interface{}{"", "bad wolf", "Dalek"}),
+	got: func(s string) string {
+		return strings.Map(unicode.ToUpper, s)
+	},
+	expectedNegateFailure: "functions behave the same for all 3 inputs, but should not",
+}, {
+	about: "SameBehavior: multiple arguments and results",
+	checker: qt.SameBehavior(func(s string, n int) (string, bool) {
+		return strings.Repeat(s, n), n > 0
+	}, []interface{}{
+		[]interface{}{"a", 3},
+		[]interface{}{"", 0},
+	}),
+	got: func(s string, n int) (string, bool) {
+		var r string
+		for i := 0; i < n; i++ {
+			r += s
+		}
+		return r, n > 0
+	},
+	expectedNegateFailure: "functions behave the same for all 2 inputs, but should not",
+}, {
+	about:                "SameBehavior: different results",
+	checker:              qt.SameBehavior(strings.ToUpper, []interface{}{"", "bad wolf", "Dalek"}),
+	got:                  strings.ToLower,
+	expectedCheckFailure: "functions behave differently for input 1:\n(input)\n\t\"bad wolf\"\n(-got +reference)\n",
+}, {
+	about:   "SameBehavior: functions returning errors",
+	checker: qt.SameBehavior(strconv.Atoi, []interface{}{"", "42", "-1", "bad wolf"}),
+	got:     strconv.Atoi,
+	expectedNegateFailure: "functions behave the same for all 4 inputs, but should not",
+}, {
+	about: "SameBehavior: different errors",
+	checker: qt.SameBehavior(strconv.Atoi, []interface{}{"42", ""}),
+	got: func(s string) (int, error) {
+		if s == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(s)
+	},
+	expectedCheckFailure: "functions behave differently for input 1:\n(input)\n\t\"\"\n(-got +reference)\n",
+}, {
+	about: "SameBehavior: different results with unexported fields",
+	checker: qt.SameBehavior(func(s string) struct{ n int } {
+		return struct{ n int }{n: len(s)}
+	}, []interface{}{"", "bad wolf"}),
+	got: func(s string) struct{ n int } {
+		return struct{ n int }{}
+	},
+	expectedCheckFailure: "functions behave differently for input 1:\n(input)\n\t\"bad wolf\"\n(got results)\n\t[{0}]\n(reference results)\n\t[{8}]\n",
+}, {
+	about: "SameBehavior: function panics",
+	checker: qt.SameBehavior(strings.ToUpper, []interface{}{"bad wolf"}),
+	got: func(s string) string {
+		panic("bad wolf")
+	},
+	expectedCheckFailure: "functions behave differently for input 0:\n(input)\n\t\"bad wolf\"\n(got panic)\n\tbad wolf\n(reference results)\n\t[BAD WOLF]\n",
+}, {
+	about: "SameBehavior: reference function panics",
+	checker: qt.SameBehavior(func(s string) string {
+		panic("exterminate")
+	}, []interface{}{"bad wolf"}),
+	got:                  strings.ToUpper,
+	expectedCheckFailure: "functions behave differently for input 0:\n(input)\n\t\"bad wolf\"\n(got results)\n\t[BAD WOLF]\n(reference panic)\n\texterminate\n",
+}, {
+	about: "SameBehavior: both functions panic the same way",
+	checker: qt.SameBehavior(func(s string) string {
+		panic("bad wolf")
+	}, []interface{}{"bad wolf"}),
+	got: func(s string) string {
+		panic("bad wolf")
+	},
+	expectedNegateFailure: "functions behave the same for all 1 inputs, but should not",
+}, {
+	about: "SameBehavior: both functions panic differently",
+	checker: qt.SameBehavior(func(s string) string {
+		panic("exterminate")
+	}, []interface{}{"bad wolf"}),
+	got: func(s string) string {
+		panic("bad wolf")
+	},
+	expectedCheckFailure: "functions behave differently for input 0:\n(input)\n\t\"bad wolf\"\n(got panic)\n\tbad wolf\n(reference panic)\n\texterminate\n",
+}, {
+	about:                 "SameBehavior: different signatures",
+	checker:               qt.SameBehavior(strings.ToUpper, []interface{}{"bad wolf"}),
+	got:                   strings.Count,
+	expectedCheckFailure:  "function signatures do not match: got func(string, string) int, reference func(string) string\n",
+	expectedNegateFailure: "function signatures do not match: got func(string, string) int, reference func(string) string\n",
+}, {
+	about:                 "SameBehavior: invalid input",
+	checker:               qt.SameBehavior(strings.ToUpper, []interface{}{"bad wolf", 42}),
+	got:                   strings.ToUpper,
+	expectedCheckFailure:  "invalid input 1: cannot use int as argument of type string\n",
+	expectedNegateFailure: "invalid input 1: cannot use int as argument of type string\n",
+}, {
+	about:                 "SameBehavior: invalid multiple arguments input",
+	checker:               qt.SameBehavior(strings.Count, []interface{}{"bad wolf"}),
+	got:                   strings.Count,
+	expectedCheckFailure:  "invalid input 0: expected a []interface{} holding 2 arguments, got string\n",
+	expectedNegateFailure: "invalid input 0: expected a []interface{} holding 2 arguments, got string\n",
+}, {
+	about:                 "SameBehavior: not a function",
+	checker:               qt.SameBehavior(strings.ToUpper, nil),
+	got:                   "bad wolf",
+	expectedCheckFailure:  "expected a function, got string instead\n",
+	expectedNegateFailure: "expected a function, got string instead\n",
+}, {
+	about:                 "SameBehavior: reference not a function",
+	checker:               qt.SameBehavior(nil, nil),
+	got:                   strings.ToUpper,
+	expectedCheckFailure:  "reference must be a function, got <nil> instead\n",
+	expectedNegateFailure: "reference must be a function, got <nil> instead\n",
 }, {
 	about:   "Matches: perfect match",
 	checker: qt.Matches,
@@ -211,6 +1184,12 @@ var checkerTests = []struct {
 	got:                  "voyages",
 	args:                 []interface{}{"these are the voyages"},
 	expectedCheckFailure: "string mismatch:\n(-text +pattern)\n\t-: \"voyages\"\n\t+: \"these are the voyages\"\n",
+}, {
+	about:                "Matches: mismatch with multi-line string",
+	checker:              qt.Matches,
+	got:                  "these are\nthe voyages",
+	args:                 []interface{}{"these are the voyages"},
+	expectedCheckFailure: "string mismatch:\n(-text +pattern)\n\t-: \"\"\"\n\t\tthese are\n\t\tthe voyages\"\"\"\n\t+: \"these are the voyages\"\n",
 }, {
 	about:                "Matches: mismatch with stringer",
 	checker:              qt.Matches,
@@ -262,6 +1241,139 @@ var checkerTests = []struct {
 	args:                  []interface{}{"these are the .*", nil},
 	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected <nil>\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected <nil>\n",
+}, {
+	about:   "ContainsAll: all substrings found",
+	checker: qt.ContainsAll("these", "voyages"),
+	got:     "these are the voyages",
+	expectedNegateFailure: "string contains all the given substrings, but should not:\n(value)\n\t\"these are the voyages\"\n(substrings)\n\t\"these\"\n\t\"voyages\"\n",
+}, {
+	about:                "ContainsAll: missing substrings",
+	checker:              qt.ContainsAll("bad", "are", "wolf"),
+	got:                  "these are the voyages",
+	expectedCheckFailure: "string does not contain all the given substrings:\n(value)\n\t\"these are the voyages\"\n(missing)\n\t\"bad\"\n\t\"wolf\"\n",
+}, {
+	about:                "ContainsAll: multi-line string",
+	checker:              qt.ContainsAll("wolf"),
+	got:                  "these are\nthe voyages",
+	expectedCheckFailure: "string does not contain all the given substrings:\n(value)\n\t\"these are\\nthe voyages\"\n(missing)\n\t\"wolf\"\n",
+}, {
+	about:                 "ContainsAll: no substrings",
+	checker:               qt.ContainsAll(),
+	got:                   "these are the voyages",
+	expectedCheckFailure:  "no substrings provided\n",
+	expectedNegateFailure: "no substrings provided\n",
+}, {
+	about:                 "ContainsAll: not a string",
+	checker:               qt.ContainsAll("42"),
+	got:                   42,
+	expectedCheckFailure:  "expected a string, got int instead\n",
+	expectedNegateFailure: "expected a string, got int instead\n",
+}, {
+	about:   "ContainsAny: some substrings found",
+	checker: qt.ContainsAny("bad", "voyages", "the"),
+	got:     "these are the voyages",
+	expectedNegateFailure: "string contains some of the given substrings, but should not:\n(value)\n\t\"these are the voyages\"\n(found)\n\t\"voyages\"\n\t\"the\"\n",
+}, {
+	about:                "ContainsAny: no substrings found",
+	checker:              qt.ContainsAny("bad", "wolf"),
+	got:                  "these are the voyages",
+	expectedCheckFailure: "string does not contain any of the given substrings:\n(value)\n\t\"these are the voyages\"\n(substrings)\n\t\"bad\"\n\t\"wolf\"\n",
+}, {
+	about:                 "ContainsAny: no substrings",
+	checker:               qt.ContainsAny(),
+	got:                   "these are the voyages",
+	expectedCheckFailure:  "no substrings provided\n",
+	expectedNegateFailure: "no substrings provided\n",
+}, {
+	about:   "StringsTo: equal",
+	checker: qt.StringsTo("March"),
+	got:     time.March,
+	expectedNegateFailure: "string representation is equal to the expected string, but should not:\n(type)\n\ttime.Month\n(string)\n\t\"March\"\n",
+}, {
+	about:                "StringsTo: not equal",
+	checker:              qt.StringsTo("1s"),
+	got:                  1500 * time.Millisecond,
+	expectedCheckFailure: "string representation is not equal to the expected string:\n(type)\n\ttime.Duration\n(value)\n\t1500000000\n(-got +want)\n\t-: \"1.5s\"\n\t+: \"1s\"\n",
+}, {
+	about:                 "StringsTo: not a stringer",
+	checker:               qt.StringsTo("42"),
+	got:                   42,
+	expectedCheckFailure:  "expected a fmt.Stringer, got int instead\n",
+	expectedNegateFailure: "expected a fmt.Stringer, got int instead\n",
+}, {
+	about:   "StringsToMatch: match",
+	checker: qt.StringsToMatch(`1\.\d+s`),
+	got:     1500 * time.Millisecond,
+	expectedNegateFailure: "string representation matches the pattern, but should not:\n(type)\n\ttime.Duration\n(string)\n\t\"1.5s\"\n(pattern)\n\t\"1\\\\.\\\\d+s\"\n",
+}, {
+	about:                "StringsToMatch: mismatch",
+	checker:              qt.StringsToMatch("Ma"),
+	got:                  time.March,
+	expectedCheckFailure: "string representation does not match the pattern:\n(type)\n\ttime.Month\n(value)\n\t3\n(string)\n\t\"March\"\n(pattern)\n\t\"Ma\"\n",
+}, {
+	about:                 "StringsToMatch: invalid pattern",
+	checker:               qt.StringsToMatch("("),
+	got:                   time.March,
+	expectedCheckFailure:  "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`\n",
+	expectedNegateFailure: "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`\n",
+}, {
+	about:                 "StringsToMatch: not a stringer",
+	checker:               qt.StringsToMatch(".*"),
+	got:                   "March",
+	expectedCheckFailure:  "expected a fmt.Stringer, got string instead\n",
+	expectedNegateFailure: "expected a fmt.Stringer, got string instead\n",
+}, {
+	about:   "MatchesCaptures: match",
+	checker: qt.MatchesCaptures(`level=(?P<level>\w+) msg=(?P<msg>.*)`),
+	got:     "level=error msg=bad wolf",
+	args:    []interface{}{map[string]string{"level": "error", "msg": "bad wolf"}},
+	expectedNegateFailure: "\"level=error msg=bad wolf\" matches \"level=(?P<level>\\\\w+) msg=(?P<msg>.*)\" with the expected captures, but should not:\n(captures)\n\tmap[string]string{\"level\":\"error\", \"msg\":\"bad wolf\"}\n",
+}, {
+	about:   "MatchesCaptures: partial captures",
+	checker: qt.MatchesCaptures(`(?P<key>\w+)=(?P<value>\w+)`),
+	got:     "answer=42",
+	args:    []interface{}{map[string]string{"value": "42"}},
+	expectedNegateFailure: "\"answer=42\" matches \"(?P<key>\\\\w+)=(?P<value>\\\\w+)\" with the expected captures, but should not:\n",
+}, {
+	about:                "MatchesCaptures: captures mismatch",
+	checker:              qt.MatchesCaptures(`level=(?P<level>\w+) msg=(?P<msg>.*)`),
+	got:                  "level=info msg=bad wolf",
+	args:                 []interface{}{map[string]string{"level": "error", "msg": "bad wolf"}},
+	expectedCheckFailure: "captures are not equal:\n(value)\n\t\"level=info msg=bad wolf\"\n(pattern)\n\t\"level=(?P<level>\\\\w+) msg=(?P<msg>.*)\"\n(-got +want)\n\t-: map[string]string{\"level\":\"info\", \"msg\":\"bad wolf\"}\n\t+: map[string]string{\"level\":\"error\", \"msg\":\"bad wolf\"}\n",
+}, {
+	about:                "MatchesCaptures: no match",
+	checker:              qt.MatchesCaptures(`level=(?P<level>\w+)`),
+	got:                  "msg=bad wolf",
+	args:                 []interface{}{map[string]string{"level": "error"}},
+	expectedCheckFailure: "string mismatch:\n(-text +pattern)\n\t-: \"msg=bad wolf\"\n\t+: \"level=(?P<level>\\\\w+)\"\n",
+}, {
+	about:                 "MatchesCaptures: unknown group",
+	checker:               qt.MatchesCaptures(`level=(?P<level>\w+)`),
+	got:                   "level=error",
+	args:                  []interface{}{map[string]string{"msg": "bad wolf"}},
+	expectedCheckFailure:  "regular expression \"level=(?P<level>\\\\w+)\" has no group named \"msg\"\n",
+	expectedNegateFailure: "regular expression \"level=(?P<level>\\\\w+)\" has no group named \"msg\"\n",
+}, {
+	about:                 "MatchesCaptures: invalid pattern",
+	checker:               qt.MatchesCaptures(`(?P<level>`),
+	got:                   "level=error",
+	args:                  []interface{}{map[string]string{}},
+	expectedCheckFailure:  "cannot compile regular expression \"(?P<level>\": ",
+	expectedNegateFailure: "cannot compile regular expression \"(?P<level>\": ",
+}, {
+	about:                 "MatchesCaptures: captures not a map",
+	checker:               qt.MatchesCaptures(`.*`),
+	got:                   "level=error",
+	args:                  []interface{}{"error"},
+	expectedCheckFailure:  "expected captures must be a map[string]string, got string instead\n",
+	expectedNegateFailure: "expected captures must be a map[string]string, got string instead\n",
+}, {
+	about:                 "MatchesCaptures: not a string",
+	checker:               qt.MatchesCaptures(`.*`),
+	got:                   42,
+	args:                  []interface{}{map[string]string{}},
+	expectedCheckFailure:  "expected a string, got int instead\n",
+	expectedNegateFailure: "expected a string, got int instead\n",
 }, {
 	about:   "ErrorMatches: perfect match",
 	checker: qt.ErrorMatches,
@@ -332,6 +1444,262 @@ var checkerTests = []struct {
 	args:                  []interface{}{"error: bad wolf", []string{"bad", "wolf"}},
 	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected [bad wolf]\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected [bad wolf]\n",
+}, {
+	about:   "ErrorMatchesChain: outer error match",
+	checker: qt.ErrorMatchesChain,
+	got:     &wrappingError{msg: "cannot open", err: errors.New("permission denied")},
+	args:    []interface{}{"cannot open: .*"},
+	expectedNegateFailure: `error "cannot open: permission denied" matches "cannot open: .*", but should not`,
+}, {
+	about:   "ErrorMatchesChain: wrapped error match",
+	checker: qt.ErrorMatchesChain,
+	got:     &wrappingError{msg: "cannot open", err: errors.New("permission denied")},
+	args:    []interface{}{"permission .*"},
+	expectedNegateFailure: `error "permission denied" wrapped by "cannot open: permission denied" matches "permission .*", but should not`,
+}, {
+	about:                "ErrorMatchesChain: mismatch",
+	checker:              qt.ErrorMatchesChain,
+	got:                  &wrappingError{msg: "cannot open", err: errors.New("permission denied")},
+	args:                 []interface{}{"file not found"},
+	expectedCheckFailure: "no error in the chain matches the pattern:\n(pattern)\n\t\"file not found\"\n(errors)\n\t\"cannot open: permission denied\"\n\t\"permission denied\"\n",
+}, {
+	about:                 "ErrorMatchesChain: invalid pattern",
+	checker:               qt.ErrorMatchesChain,
+	got:                   errors.New("bad wolf"),
+	args:                  []interface{}{"("},
+	expectedCheckFailure:  "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`",
+	expectedNegateFailure: "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`",
+}, {
+	about:                 "ErrorMatchesChain: not an error",
+	checker:               qt.ErrorMatchesChain,
+	got:                   42,
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "did not get an error, got int instead",
+	expectedNegateFailure: "did not get an error, got int instead",
+}, {
+	about:                 "ErrorMatchesChain: nil error",
+	checker:               qt.ErrorMatchesChain,
+	got:                   nil,
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "did not get an error, got <nil> instead\n",
+	expectedNegateFailure: "did not get an error, got <nil> instead\n",
+}, {
+	about:   "WrapsWithMessage: success",
+	checker: qt.WrapsWithMessage(io.EOF, "cannot read: .*"),
+	got:     &wrappingError{msg: "cannot read", err: io.EOF},
+	expectedNegateFailure: "error wraps the cause and its message matches the pattern, but should not:\n(error)\n\t\"cannot read: EOF\"\n(cause)\n\t\"EOF\"\n(pattern)\n\t\"cannot read: .*\"\n",
+}, {
+	about:   "WrapsWithMessage: same error",
+	checker: qt.WrapsWithMessage(io.EOF, "EOF"),
+	got:     io.EOF,
+	expectedNegateFailure: "error wraps the cause and its message matches the pattern, but should not:\n(error)\n\t\"EOF\"\n(cause)\n\t\"EOF\"\n(pattern)\n\t\"EOF\"\n",
+}, {
+	about:                "WrapsWithMessage: message mismatch",
+	checker:              qt.WrapsWithMessage(io.EOF, "cannot write: .*"),
+	got:                  &wrappingError{msg: "cannot read", err: io.EOF},
+	expectedCheckFailure: "error message does not match the pattern:\n(error)\n\t\"cannot read: EOF\"\n(cause)\n\t\"EOF\"\n(pattern)\n\t\"cannot write: .*\"\n(cause wrapped)\n\ttrue\n(message matches)\n\tfalse\n",
+}, {
+	about:                "WrapsWithMessage: cause not wrapped",
+	checker:              qt.WrapsWithMessage(io.EOF, "cannot read: .*"),
+	got:                  &wrappingError{msg: "cannot read", err: errors.New("EOF")},
+	expectedCheckFailure: "error does not wrap the expected cause:\n(error)\n\t\"cannot read: EOF\"\n(cause)\n\t\"EOF\"\n(pattern)\n\t\"cannot read: .*\"\n(cause wrapped)\n\tfalse\n(message matches)\n\ttrue\n",
+}, {
+	about:                "WrapsWithMessage: both mismatch",
+	checker:              qt.WrapsWithMessage(io.EOF, "cannot write: .*"),
+	got:                  errors.New("bad wolf"),
+	expectedCheckFailure: "error does not wrap the expected cause, and its message does not match the pattern:\n(error)\n\t\"bad wolf\"\n(cause)\n\t\"EOF\"\n(pattern)\n\t\"cannot write: .*\"\n(cause wrapped)\n\tfalse\n(message matches)\n\tfalse\n",
+}, {
+	about:                 "WrapsWithMessage: invalid pattern",
+	checker:               qt.WrapsWithMessage(io.EOF, "("),
+	got:                   io.EOF,
+	expectedCheckFailure:  "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`",
+	expectedNegateFailure: "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`",
+}, {
+	about:                 "WrapsWithMessage: nil cause",
+	checker:               qt.WrapsWithMessage(nil, ".*"),
+	got:                   io.EOF,
+	expectedCheckFailure:  "cause must not be nil",
+	expectedNegateFailure: "cause must not be nil",
+}, {
+	about:                 "WrapsWithMessage: not an error",
+	checker:               qt.WrapsWithMessage(io.EOF, ".*"),
+	got:                   42,
+	expectedCheckFailure:  "did not get an error, got int instead",
+	expectedNegateFailure: "did not get an error, got int instead",
+}, {
+	about:   "ErrorIs: same error",
+	checker: qt.ErrorIs,
+	got:     io.EOF,
+	args:    []interface{}{io.EOF},
+	expectedNegateFailure: "error \"EOF\" is target \"EOF\", but should not be:\n(error)\n\t\"EOF\"\n(matching error)\n\t\"EOF\"\n(target)\n\t\"EOF\"\n",
+}, {
+	about:   "ErrorIs: wrapped error",
+	checker: qt.ErrorIs,
+	got: &wrappingError{
+		msg: "cannot read",
+		err: &wrappingError{msg: "bad wolf", err: io.ErrUnexpectedEOF},
+	},
+	args:                  []interface{}{io.ErrUnexpectedEOF},
+	expectedNegateFailure: "error \"cannot read: bad wolf: unexpected EOF\" is target \"unexpected EOF\", but should not be:\n(error)\n\t\"cannot read: bad wolf: unexpected EOF\"\n(matching error)\n\t\"unexpected EOF\"\n(target)\n\t\"unexpected EOF\"\n",
+}, {
+	about:                "ErrorIs: different error",
+	checker:              qt.ErrorIs,
+	got:                  &wrappingError{msg: "bad wolf", err: io.EOF},
+	args:                 []interface{}{io.ErrUnexpectedEOF},
+	expectedCheckFailure: "error is not the target:\n(error)\n\t\"bad wolf: EOF\"\n(target)\n\t\"unexpected EOF\"\n",
+}, {
+	about:                "ErrorIs: nil error",
+	checker:              qt.ErrorIs,
+	got:                  nil,
+	args:                 []interface{}{io.EOF},
+	expectedCheckFailure: "error is nil, therefore it is not the target:\n(target)\n\t\"EOF\"\n",
+}, {
+	about:                 "ErrorIs: nil target",
+	checker:               qt.ErrorIs,
+	got:                   io.EOF,
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "target must be a non-nil error, got <nil> instead\n",
+	expectedNegateFailure: "target must be a non-nil error, got <nil> instead\n",
+}, {
+	about:                 "ErrorIs: not an error",
+	checker:               qt.ErrorIs,
+	got:                   42,
+	args:                  []interface{}{io.EOF},
+	expectedCheckFailure:  "did not get an error, got int instead\n",
+	expectedNegateFailure: "did not get an error, got int instead\n",
+}, {
+	about:   "ErrorOfType: same type",
+	checker: qt.ErrorOfType,
+	got:     &verboseError{msg: "bad wolf"},
+	args:    []interface{}{(*verboseError)(nil)},
+	expectedNegateFailure: "error is of type *quicktest_test.verboseError, but should not:\n(error)\n\tbad wolf\n",
+}, {
+	about:   "ErrorOfType: wrapped error",
+	checker: qt.ErrorOfType,
+	got:     &wrappingError{msg: "cannot exterminate", err: &verboseError{msg: "bad wolf"}},
+	args:    []interface{}{(*verboseError)(nil)},
+	expectedNegateFailure: "error is of type *quicktest_test.verboseError, but should not:\n(error)\n\tcannot exterminate: bad wolf\n",
+}, {
+	about:                "ErrorOfType: different type",
+	checker:              qt.ErrorOfType,
+	got:                  errors.New("bad wolf"),
+	args:                 []interface{}{(*verboseError)(nil)},
+	expectedCheckFailure: "error type mismatch:\n(-got +want)\n\t-: *errors.errorString\n\t+: *quicktest_test.verboseError\n(error)\n\tbad wolf\n",
+}, {
+	about:                "ErrorOfType: different wrapped type",
+	checker:              qt.ErrorOfType,
+	got:                  &wrappingError{msg: "cannot exterminate", err: errors.New("bad wolf")},
+	args:                 []interface{}{(*verboseError)(nil)},
+	expectedCheckFailure: "error type mismatch:\n(-got +want)\n\t-: *quicktest_test.wrappingError\n\t+: *quicktest_test.verboseError\n(error)\n\tcannot exterminate: bad wolf\n",
+}, {
+	about:                "ErrorOfType: nil error",
+	checker:              qt.ErrorOfType,
+	got:                  nil,
+	args:                 []interface{}{(*verboseError)(nil)},
+	expectedCheckFailure: "error is nil, therefore it is not of type *quicktest_test.verboseError\n",
+}, {
+	about:                 "ErrorOfType: not an error",
+	checker:               qt.ErrorOfType,
+	got:                   42,
+	args:                  []interface{}{(*verboseError)(nil)},
+	expectedCheckFailure:  "did not get an error, got int instead",
+	expectedNegateFailure: "did not get an error, got int instead",
+}, {
+	about:                 "ErrorOfType: nil type",
+	checker:               qt.ErrorOfType,
+	got:                   errors.New("bad wolf"),
+	args:                  []interface{}{nil},
+	expectedCheckFailure:  "expected error type must be provided as a typed value, got nil",
+	expectedNegateFailure: "expected error type must be provided as a typed value, got nil",
+}, {
+	about:                 "ErrorOfType: not enough arguments",
+	checker:               qt.ErrorOfType,
+	expectedCheckFailure:  "not enough arguments provided to checker: got 0, want 1\n",
+	expectedNegateFailure: "not enough arguments provided to checker: got 0, want 1\n",
+}, {
+	about:   "IsType: same type",
+	checker: qt.IsType((*int)(nil)),
+	got:     42,
+	expectedNegateFailure: "value is of type int, but should not:\n(value)\n\t42\n",
+}, {
+	about:   "IsType: interface{} holding a map",
+	checker: qt.IsType((*map[string]interface{})(nil)),
+	got:     interface{}(map[string]interface{}{"answer": 42}),
+	expectedNegateFailure: "value is of type map[string]interface {}, but should not:\n(value)\n\tmap[string]interface {}{\"answer\":42}\n",
+}, {
+	about:                "IsType: different type",
+	checker:              qt.IsType((*int)(nil)),
+	got:                  42.0,
+	expectedCheckFailure: "type mismatch:\n(-got +want)\n\t-: float64\n\t+: int\n(value)\n\t42\n",
+}, {
+	about:                "IsType: interface type",
+	checker:              qt.IsType((*io.Reader)(nil)),
+	got:                  &bytes.Buffer{},
+	expectedCheckFailure: "type mismatch:\n(-got +want)\n\t-: *bytes.Buffer\n\t+: io.Reader\n(value)\n\t&bytes.Buffer{",
+}, {
+	about:                "IsType: nil value",
+	checker:              qt.IsType((*error)(nil)),
+	got:                  nil,
+	expectedCheckFailure: "value is nil, therefore it has no dynamic type:\n(want)\n\terror\n",
+}, {
+	about:                 "IsType: type not provided as a pointer",
+	checker:               qt.IsType(0),
+	got:                   42,
+	expectedCheckFailure:  "type must be provided as a typed nil pointer, like (*int)(nil), got int instead\n",
+	expectedNegateFailure: "type must be provided as a typed nil pointer, like (*int)(nil), got int instead\n",
+}, {
+	about:                 "IsType: nil type",
+	checker:               qt.IsType(nil),
+	got:                   42,
+	expectedCheckFailure:  "type must be provided as a typed nil pointer, like (*int)(nil), got <nil> instead\n",
+	expectedNegateFailure: "type must be provided as a typed nil pointer, like (*int)(nil), got <nil> instead\n",
+}, {
+	about:   "IsAssignableTo: interface type",
+	checker: qt.IsAssignableTo((*io.Reader)(nil)),
+	got:     strings.NewReader("bad wolf"),
+	expectedNegateFailure: "value of type *strings.Reader is assignable to io.Reader, but should not:\n(value)\n\t&strings.Reader{",
+}, {
+	about:   "IsAssignableTo: same type",
+	checker: qt.IsAssignableTo((*string)(nil)),
+	got:     "bad wolf",
+	expectedNegateFailure: "value of type string is assignable to string, but should not:\n(value)\n\t\"bad wolf\"\n",
+}, {
+	about:                "IsAssignableTo: not assignable",
+	checker:              qt.IsAssignableTo((*io.Writer)(nil)),
+	got:                  strings.NewReader("bad wolf"),
+	expectedCheckFailure: "value of type *strings.Reader is not assignable to io.Writer:\n(value)\n\t&strings.Reader{",
+}, {
+	about:                "IsAssignableTo: nil value",
+	checker:              qt.IsAssignableTo((*io.Reader)(nil)),
+	got:                  nil,
+	expectedCheckFailure: "value is nil, therefore it has no dynamic type:\n(want)\n\tio.Reader\n",
+}, {
+	about:   "DoesNotPanic: no panic",
+	checker: qt.DoesNotPanic,
+	got:     func() {},
+	expectedNegateFailure: "the function did not panic\n",
+}, {
+	about:                "DoesNotPanic: panic",
+	checker:              qt.DoesNotPanic,
+	got:                  func() { panic("bad wolf") },
+	expectedCheckFailure: "the function panicked:\n(panic)\n\tbad wolf\n(stack)\n\tgithub.com/frankban/quicktest_test.init.func",
+}, {
+	about:                "DoesNotPanic: error panic",
+	checker:              qt.DoesNotPanic,
+	got:                  func() { var m map[string]int; m["answer"] = 42 },
+	expectedCheckFailure: "the function panicked:\n(panic)\n\tassignment to entry in nil map\n(stack)\n\tgithub.com/frankban/quicktest_test.init.func",
+}, {
+	about:                 "DoesNotPanic: not a function",
+	checker:               qt.DoesNotPanic,
+	got:                   42,
+	expectedCheckFailure:  "expected a function, got int instead\n",
+	expectedNegateFailure: "expected a function, got int instead\n",
+}, {
+	about:                 "DoesNotPanic: function with arguments",
+	checker:               qt.DoesNotPanic,
+	got:                   func(int) {},
+	expectedCheckFailure:  "expected a function accepting no arguments, got func(int) instead\n",
+	expectedNegateFailure: "expected a function accepting no arguments, got func(int) instead\n",
 }, {
 	about:   "PanicMatches: perfect match",
 	checker: qt.PanicMatches,
@@ -414,6 +1782,330 @@ var checkerTests = []struct {
 	args:                  []interface{}{"error: bad wolf", 42},
 	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected 42\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected 42\n",
+}, {
+	about:   "PanicMatchesTimeout: match",
+	checker: qt.PanicMatchesTimeout(time.Minute),
+	got:     func() { panic("error: bad wolf") },
+	args:    []interface{}{"error: .*"},
+	expectedNegateFailure: `there was a panic matching "error: .*"`,
+}, {
+	about:                "PanicMatchesTimeout: mismatch",
+	checker:              qt.PanicMatchesTimeout(time.Minute),
+	got:                  func() { panic("error: bad wolf") },
+	args:                 []interface{}{"error: exterminate"},
+	expectedCheckFailure: "panic message mismatch:\n(-text +pattern)\n\t-: \"error: bad wolf\"\n\t+: \"error: exterminate\"\n",
+}, {
+	about:                "PanicMatchesTimeout: no panic",
+	checker:              qt.PanicMatchesTimeout(time.Minute),
+	got:                  func() {},
+	args:                 []interface{}{".*"},
+	expectedCheckFailure: "the function did not panic",
+}, {
+	about:                 "PanicMatchesTimeout: function not returning in time",
+	checker:               qt.PanicMatchesTimeout(time.Millisecond),
+	got:                   func() { time.Sleep(100 * time.Millisecond) },
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "the function did not return (possible deadlock) within 1ms\n",
+	expectedNegateFailure: "the function did not return (possible deadlock) within 1ms\n",
+}, {
+	about:                 "PanicMatchesTimeout: not a function",
+	checker:               qt.PanicMatchesTimeout(time.Minute),
+	got:                   map[string]int{"answer": 42},
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "expected a function, got map[string]int instead",
+	expectedNegateFailure: "expected a function, got map[string]int instead",
+}, {
+	about:   "IsValidUTF8: valid string",
+	checker: qt.IsValidUTF8,
+	got:     "these are the voyages ✓",
+	expectedNegateFailure: "the provided value is valid UTF-8, but should not:\n(value)\n\t\"these are the voyages ✓\"\n",
+}, {
+	about:   "IsValidUTF8: empty bytes",
+	checker: qt.IsValidUTF8,
+	got:     []byte{},
+	expectedNegateFailure: "the provided value is valid UTF-8, but should not:\n(value)\n\t\"\"\n",
+}, {
+	about:                "IsValidUTF8: invalid string",
+	checker:              qt.IsValidUTF8,
+	got:                  "bad ✓ \xffwolf",
+	expectedCheckFailure: "invalid UTF-8 sequence at byte offset 8:\n(value)\n\t\"bad ✓ \\xffwolf\"\n",
+}, {
+	about:                "IsValidUTF8: invalid bytes",
+	checker:              qt.IsValidUTF8,
+	got:                  []byte("\xe2\x9c"),
+	expectedCheckFailure: "invalid UTF-8 sequence at byte offset 0:\n(value)\n\t\"\\xe2\\x9c\"\n",
+}, {
+	about:                 "IsValidUTF8: not a string",
+	checker:               qt.IsValidUTF8,
+	got:                   42,
+	expectedCheckFailure:  "expected a string or a []byte, got int instead\n",
+	expectedNegateFailure: "expected a string or a []byte, got int instead\n",
+}, {
+	about:   "IsValidJSON: valid string",
+	checker: qt.IsValidJSON,
+	got:     `{"name": "bad wolf", "answers": [42, 47]}`,
+	expectedNegateFailure: "the provided value is valid JSON, but should not:\n(value)\n\t\"{\\\"name\\\": \\\"bad wolf\\\", \\\"answers\\\": [42, 47]}\"\n",
+}, {
+	about:   "IsValidJSON: valid bytes",
+	checker: qt.IsValidJSON,
+	got:     []byte("42"),
+	expectedNegateFailure: "the provided value is valid JSON, but should not:\n(value)\n\t\"42\"\n",
+}, {
+	about:                "IsValidJSON: invalid document",
+	checker:              qt.IsValidJSON,
+	got:                  `{"name": bad wolf}`,
+	expectedCheckFailure: "invalid JSON at byte offset 10: invalid character 'b' looking for beginning of value\n(value)\n\t\"{\\\"name\\\": bad wolf}\"\n",
+}, {
+	about:                "IsValidJSON: empty document",
+	checker:              qt.IsValidJSON,
+	got:                  "",
+	expectedCheckFailure: "invalid JSON at byte offset 0: unexpected end of JSON input\n(value)\n\t\"\"\n",
+}, {
+	about:                 "IsValidJSON: not a document",
+	checker:               qt.IsValidJSON,
+	got:                   42,
+	expectedCheckFailure:  "expected a JSON string or []byte, got int instead\n",
+	expectedNegateFailure: "expected a JSON string or []byte, got int instead\n",
+}, {
+	about: "MarshalsToJSON: success",
+	checker: qt.MarshalsToJSON(`{"name": "bad wolf", "id": 42}`),
+	got: struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}{ID: 42, Name: "bad wolf"},
+	expectedNegateFailure: "value marshals to the expected JSON, but should not:\n(json)\n\t{\"id\":42,\"name\":\"bad wolf\"}\n",
+}, {
+	about: "MarshalsToJSON: mismatch",
+	checker: qt.MarshalsToJSON(`{"id": 47, "name": "bad wolf"}`),
+	got: struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}{ID: 42, Name: "bad wolf"},
+	expectedCheckFailure: "value does not marshal to the expected JSON:\n(json)\n\t{\"id\":42,\"name\":\"bad wolf\"}\n(diff)\n\t--- got\n\t+++ want\n",
+}, {
+	about:                "MarshalsToJSON: missing tags",
+	checker:              qt.MarshalsToJSON(`{"id": 42}`),
+	got:                  struct{ ID int }{ID: 42},
+	expectedCheckFailure: "value does not marshal to the expected JSON:\n(json)\n\t{\"ID\":42}\n(diff)\n",
+}, {
+	about:                 "MarshalsToJSON: marshal error",
+	checker:               qt.MarshalsToJSON(`{}`),
+	got:                   make(chan int),
+	expectedCheckFailure:  "cannot marshal value to JSON: json: unsupported type: chan int\n",
+	expectedNegateFailure: "cannot marshal value to JSON: json: unsupported type: chan int\n",
+}, {
+	about:                 "MarshalsToJSON: invalid expected JSON",
+	checker:               qt.MarshalsToJSON(`{`),
+	got:                   42,
+	expectedCheckFailure:  "cannot parse expected JSON: unexpected end of JSON input\n",
+	expectedNegateFailure: "cannot parse expected JSON: unexpected end of JSON input\n",
+}, {
+	about:   "XMLEquals: same documents",
+	checker: qt.XMLEquals,
+	got:     `<user id="42" admin="true"><name>bad wolf</name></user>`,
+	args: []interface{}{[]byte(`<?xml version="1.0"?>
+<!-- The user. -->
+<user admin="true" id="42">
+    <name>
+        bad wolf
+    </name>
+</user>
+`)},
+	expectedNegateFailure: "XML documents are equal, but should not:\n(value)\n\t<user admin=\"true\" id=\"42\">\n\t  <name>\n\t    \"bad wolf\"\n\t  </name>\n\t</user>\n",
+}, {
+	about:   "XMLEquals: document and Go value",
+	checker: qt.XMLEquals,
+	got:     `<user id="42"><name>bad wolf</name></user>`,
+	args: []interface{}{xmlUser{
+		ID:   42,
+		Name: "bad wolf",
+	}},
+	expectedNegateFailure: "XML documents are equal, but should not:\n",
+}, {
+	about:                 "XMLEquals: same namespaces with different prefixes",
+	checker:               qt.XMLEquals,
+	got:                   `<a:user xmlns:a="urn:users"><a:name>bad wolf</a:name></a:user>`,
+	args:                  []interface{}{`<user xmlns="urn:users"><name>bad wolf</name></user>`},
+	expectedNegateFailure: "XML documents are equal, but should not:\n(value)\n\t<{urn:users}user>\n",
+}, {
+	about:                 "XMLEquals: text split by comments",
+	checker:               qt.XMLEquals,
+	got:                   `<name>bad <!-- not a --> wolf</name>`,
+	args:                  []interface{}{`<name>bad  wolf</name>`},
+	expectedNegateFailure: "XML documents are equal, but should not:\n",
+}, {
+	about:                "XMLEquals: different documents",
+	checker:              qt.XMLEquals,
+	got:                  `<user id="42"><name>bad wolf</name></user>`,
+	args:                 []interface{}{`<user id="47"><name>bad wolf</name></user>`},
+	expectedCheckFailure: "XML documents are not equal:\n(diff)\n\t--- got\n\t+++ want\n\t@@ -1,4 +1,4 @@\n\t-<user id=\"42\">\n\t+<user id=\"47\">\n\t   <name>\n\t     \"bad wolf\"\n\t   </name>\n",
+}, {
+	about:                 "XMLEquals: invalid provided XML",
+	checker:               qt.XMLEquals,
+	got:                   `<user>`,
+	args:                  []interface{}{`<user/>`},
+	expectedCheckFailure:  "cannot parse provided XML: ",
+	expectedNegateFailure: "cannot parse provided XML: ",
+}, {
+	about:                 "XMLEquals: invalid expected XML",
+	checker:               qt.XMLEquals,
+	got:                   `<user/>`,
+	args:                  []interface{}{`<user></name>`},
+	expectedCheckFailure:  "cannot parse expected XML: ",
+	expectedNegateFailure: "cannot parse expected XML: ",
+}, {
+	about:                 "XMLEquals: multiple root elements",
+	checker:               qt.XMLEquals,
+	got:                   `<user/><user/>`,
+	args:                  []interface{}{`<user/>`},
+	expectedCheckFailure:  "cannot parse provided XML: expected a single root element, found 2\n",
+	expectedNegateFailure: "cannot parse provided XML: expected a single root element, found 2\n",
+}, {
+	about:                 "XMLEquals: text outside the root element",
+	checker:               qt.XMLEquals,
+	got:                   `<user/>bad wolf`,
+	args:                  []interface{}{`<user/>`},
+	expectedCheckFailure:  "cannot parse provided XML: unexpected text \"bad wolf\" outside the root element\n",
+	expectedNegateFailure: "cannot parse provided XML: unexpected text \"bad wolf\" outside the root element\n",
+}, {
+	about:                 "XMLEquals: not an XML document",
+	checker:               qt.XMLEquals,
+	got:                   42,
+	args:                  []interface{}{`<answer>42</answer>`},
+	expectedCheckFailure:  "expected an XML string or []byte, got int instead\n",
+	expectedNegateFailure: "expected an XML string or []byte, got int instead\n",
+}, {
+	about:   "CompletesWithin: function returning in time",
+	checker: qt.CompletesWithin(time.Minute),
+	got:     func() {},
+	expectedNegateFailure: "the function returned within the deadline, but should not:\n(deadline)\n\t1m0s\n(elapsed)\n\t",
+}, {
+	about:   "CompletesWithin: function returning something",
+	checker: qt.CompletesWithin(time.Minute),
+	got:     func() error { return nil },
+	expectedNegateFailure: "the function returned within the deadline, but should not:\n",
+}, {
+	about:                "CompletesWithin: function not returning in time",
+	checker:              qt.CompletesWithin(time.Millisecond),
+	got:                  func() { time.Sleep(100 * time.Millisecond) },
+	expectedCheckFailure: "the function did not return within the deadline:\n(deadline)\n\t1ms\n(elapsed)\n\t",
+}, {
+	about:                 "CompletesWithin: function panicking",
+	checker:               qt.CompletesWithin(time.Minute),
+	got:                   func() { panic("bad wolf") },
+	expectedCheckFailure:  "the function panicked: bad wolf\n",
+	expectedNegateFailure: "the function panicked: bad wolf\n",
+}, {
+	about:                 "CompletesWithin: not a function",
+	checker:               qt.CompletesWithin(time.Minute),
+	got:                   42,
+	expectedCheckFailure:  "expected a function, got int instead\n",
+	expectedNegateFailure: "expected a function, got int instead\n",
+}, {
+	about:                 "CompletesWithin: not a proper function",
+	checker:               qt.CompletesWithin(time.Minute),
+	got:                   func(int) {},
+	expectedCheckFailure:  "expected a function accepting no arguments, got func(int) instead\n",
+	expectedNegateFailure: "expected a function accepting no arguments, got func(int) instead\n",
+}, {
+	about:   "LatencyPercentile: within the maximum",
+	checker: qt.LatencyPercentile(10, 95, time.Hour),
+	got:     func() {},
+	expectedNegateFailure: "latency percentile is within the maximum, but should not:\n(percentile)\n\tp95\n(measured)\n\t",
+}, {
+	about:   "LatencyPercentile: maximum percentile",
+	checker: qt.LatencyPercentile(3, 100, time.Hour),
+	got:     func() {},
+	expectedNegateFailure: "latency percentile is within the maximum, but should not:\n(percentile)\n\tp100\n(measured)\n\t",
+}, {
+	about:   "LatencyPercentile: exceeding the maximum",
+	checker: qt.LatencyPercentile(5, 50, time.Nanosecond),
+	got: func() {
+		time.Sleep(time.Millisecond)
+	},
+	expectedCheckFailure: "latency percentile exceeds the maximum:\n(percentile)\n\tp50\n(measured)\n\t",
+}, {
+	about:                 "LatencyPercentile: not a function",
+	checker:               qt.LatencyPercentile(10, 95, time.Second),
+	got:                   42,
+	expectedCheckFailure:  "expected a func(), got int instead\n",
+	expectedNegateFailure: "expected a func(), got int instead\n",
+}, {
+	about:                 "LatencyPercentile: invalid number of runs",
+	checker:               qt.LatencyPercentile(0, 95, time.Second),
+	got:                   func() {},
+	expectedCheckFailure:  "number of runs must be positive, got 0\n",
+	expectedNegateFailure: "number of runs must be positive, got 0\n",
+}, {
+	about:                 "LatencyPercentile: invalid percentile",
+	checker:               qt.LatencyPercentile(10, 120, time.Second),
+	got:                   func() {},
+	expectedCheckFailure:  "percentile must be greater than 0 and not greater than 100, got 120\n",
+	expectedNegateFailure: "percentile must be greater than 0 and not greater than 100, got 120\n",
+}, {
+	about:   "ContextDone: canceled",
+	checker: qt.ContextDone(context.Canceled),
+	got:     canceledContext(),
+	expectedNegateFailure: "context is done, but should not:\n(error)\n\t\"context canceled\"\n",
+}, {
+	about:   "ContextDone: any error",
+	checker: qt.ContextDone(nil),
+	got:     canceledContext(),
+	expectedNegateFailure: "context is done, but should not:\n(error)\n\t\"context canceled\"\n",
+}, {
+	about:                "ContextDone: unexpected error",
+	checker:              qt.ContextDone(context.DeadlineExceeded),
+	got:                  canceledContext(),
+	expectedCheckFailure: "context done with unexpected error:\n(error)\n\t\"context canceled\"\n(want)\n\t\"context deadline exceeded\"\n",
+}, {
+	about:                "ContextDone: not done",
+	checker:              qt.ContextDone(context.Canceled),
+	got:                  context.Background(),
+	expectedCheckFailure: "context not done:\n(error)\n\t<nil>\n",
+}, {
+	about:                 "ContextDone: not a context",
+	checker:               qt.ContextDone(context.Canceled),
+	got:                   42,
+	expectedCheckFailure:  "expected a context.Context, got int instead\n",
+	expectedNegateFailure: "expected a context.Context, got int instead\n",
+}, {
+	about:   "IsClosed: closed channel",
+	checker: qt.IsClosed,
+	got:     closedChan(),
+	expectedNegateFailure: "channel is closed, but should not\n",
+}, {
+	about:   "IsClosed: closed receive-only channel",
+	checker: qt.IsClosed,
+	got:     (<-chan int)(closedChan()),
+	expectedNegateFailure: "channel is closed, but should not\n",
+}, {
+	about:                "IsClosed: open channel",
+	checker:              qt.IsClosed,
+	got:                  make(chan int),
+	expectedCheckFailure: "channel is not closed:\n(state)\n\topen and empty\n",
+}, {
+	about:                "IsClosed: nil channel",
+	checker:              qt.IsClosed,
+	got:                  (chan int)(nil),
+	expectedCheckFailure: "channel is not closed:\n(state)\n\tnil\n",
+}, {
+	about:                 "IsClosed: buffered values",
+	checker:               qt.IsClosed,
+	got:                   bufferedChan(42, 47),
+	expectedCheckFailure:  "cannot determine whether the channel is closed: 2 buffered value(s) pending\n",
+	expectedNegateFailure: "cannot determine whether the channel is closed: 2 buffered value(s) pending\n",
+}, {
+	about:                 "IsClosed: send-only channel",
+	checker:               qt.IsClosed,
+	got:                   make(chan<- int),
+	expectedCheckFailure:  "cannot receive from send-only channel of type chan<- int\n",
+	expectedNegateFailure: "cannot receive from send-only channel of type chan<- int\n",
+}, {
+	about:                 "IsClosed: not a channel",
+	checker:               qt.IsClosed,
+	got:                   42,
+	expectedCheckFailure:  "expected a channel, got int instead\n",
+	expectedNegateFailure: "expected a channel, got int instead\n",
 }, {
 	about:   "IsNil: nil",
 	checker: qt.IsNil,
@@ -444,12 +2136,83 @@ var checkerTests = []struct {
 	checker:              qt.IsNil,
 	got:                  42,
 	expectedCheckFailure: "42 is not nil",
+}, {
+	about:                "IsNil: error",
+	checker:              qt.IsNil,
+	got:                  errors.New("bad wolf"),
+	expectedCheckFailure: "error is not nil:\n(error)\n\tbad wolf\n",
+}, {
+	about:                "IsNil: multi-line error",
+	checker:              qt.IsNil,
+	got:                  errors.New("bad wolf\nexterminate"),
+	expectedCheckFailure: "error is not nil:\n(error)\n\tbad wolf\n\texterminate\n",
+}, {
+	about:                "IsNil: error with verbose format",
+	checker:              qt.IsNil,
+	got:                  &verboseError{"bad wolf", "bad wolf\n  at main.go:42"},
+	expectedCheckFailure: "error is not nil:\n(error)\n\tbad wolf\n(verbose)\n\tbad wolf\n\t  at main.go:42\n",
+}, {
+	about:   "IsNil: nil error pointer",
+	checker: qt.IsNil,
+	got:     (*verboseError)(nil),
+	expectedNegateFailure: "the value is nil, but should not",
 }, {
 	about:                 "IsNil: too many arguments",
 	checker:               qt.IsNil,
 	args:                  []interface{}{"not nil"},
 	expectedCheckFailure:  "too many arguments provided to checker: got 1, want 0: unexpected not nil\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 1, want 0: unexpected not nil\n",
+}, {
+	about:   "IsZero: zero number",
+	checker: qt.IsZero,
+	got:     0.0,
+	expectedNegateFailure: "value is the zero value for its type, but should not:\n(value)\n\t0\n(type)\n\tfloat64\n",
+}, {
+	about:   "IsZero: zero struct",
+	checker: qt.IsZero,
+	got:     companion{},
+	expectedNegateFailure: "value is the zero value for its type, but should not:\n(value)\n\tquicktest_test.companion{Name:\"\", Age:0, Tags:[]string(nil), secret:\"\"}\n(type)\n\tquicktest_test.companion\n",
+}, {
+	about:   "IsZero: nil",
+	checker: qt.IsZero,
+	got:     nil,
+	expectedNegateFailure: "value is the zero value for its type, but should not:\n(value)\n\t<nil>\n(type)\n\t<nil>\n",
+}, {
+	about:                "IsZero: non-zero string",
+	checker:              qt.IsZero,
+	got:                  "bad wolf",
+	expectedCheckFailure: "value is not the zero value for its type:\n(value)\n\t\"bad wolf\"\n(zero value)\n\t\"\"\n",
+}, {
+	about:                "IsZero: struct with unexported field set",
+	checker:              qt.IsZero,
+	got:                  companion{secret: "bad wolf"},
+	expectedCheckFailure: "value is not the zero value for its type:\n(value)\n\tquicktest_test.companion{Name:\"\", Age:0, Tags:[]string(nil), secret:\"bad wolf\"}\n(zero value)\n\tquicktest_test.companion{Name:\"\", Age:0, Tags:[]string(nil), secret:\"\"}\n",
+}, {
+	about:                "IsZero: negative zero",
+	checker:              qt.IsZero,
+	got:                  math.Copysign(0, -1),
+	expectedCheckFailure: "value is not the zero value for its type:\n(value)\n\t-0\n(zero value)\n\t0\n",
+}, {
+	about:   "IsZero: struct with nil func",
+	checker: qt.IsZero,
+	got:     struct{ F func() }{},
+	expectedNegateFailure: "value is the zero value for its type, but should not:\n(value)\n\tstruct { F func() }{F:(func())(nil)}\n(type)\n\tstruct { F func() }\n",
+}, {
+	about:                "IsZero: struct with func set",
+	checker:              qt.IsZero,
+	got:                  struct{ F func() }{F: func() {}},
+	expectedCheckFailure: "value is not the zero value for its type:\n(value)\n\tstruct { F func() }{F:(func())(0x",
+}, {
+	about:                "IsZero: empty slice",
+	checker:              qt.IsZero,
+	got:                  []int{},
+	expectedCheckFailure: "value is not the zero value for its type:\n(value)\n\t[]int{}\n(zero value)\n\t[]int(nil)\n",
+}, {
+	about:                 "IsZero: too many arguments",
+	checker:               qt.IsZero,
+	args:                  []interface{}{0},
+	expectedCheckFailure:  "too many arguments provided to checker: got 1, want 0: unexpected 0\n",
+	expectedNegateFailure: "too many arguments provided to checker: got 1, want 0: unexpected 0\n",
 }, {
 	about:   "HasLen: arrays with the same length",
 	checker: qt.HasLen,
@@ -530,6 +2293,811 @@ var checkerTests = []struct {
 	args:                  []interface{}{"bad wolf"},
 	expectedCheckFailure:  "expected length is of type string, not int\n",
 	expectedNegateFailure: "expected length is of type string, not int\n",
+}, {
+	about:   "HasLenBetween: length in range",
+	checker: qt.HasLenBetween(1, 3),
+	got:     []int{42, 47},
+	expectedNegateFailure: "the provided value has a length of 2, in the range [1, 3], but should not:\n(value)\n\t[]int{42, 47}\n",
+}, {
+	about:   "HasLenBetween: length equal to bound",
+	checker: qt.HasLenBetween(2, 2),
+	got:     "ab",
+	expectedNegateFailure: "the provided value has a length of 2, in the range [2, 2], but should not:\n(value)\n\t\"ab\"\n",
+}, {
+	about:                "HasLenBetween: length below range",
+	checker:              qt.HasLenBetween(1, 3),
+	got:                  map[string]int{},
+	expectedCheckFailure: "the provided value has a length of 0, not in the range [1, 3]:\n(value)\n\tmap[string]int{}\n",
+}, {
+	about:                "HasLenBetween: length above range",
+	checker:              qt.HasLenBetween(0, 1),
+	got:                  [3]string{"these", "are", "the"},
+	expectedCheckFailure: "the provided value has a length of 3, not in the range [0, 1]:\n(value)\n\t[3]string{\"these\", \"are\", \"the\"}\n",
+}, {
+	about:                 "HasLenBetween: invalid range",
+	checker:               qt.HasLenBetween(3, 1),
+	got:                   []int{42},
+	expectedCheckFailure:  "invalid length range: minimum 3 is greater than maximum 1\n",
+	expectedNegateFailure: "invalid length range: minimum 3 is greater than maximum 1\n",
+}, {
+	about:                 "HasLenBetween: value without a length",
+	checker:               qt.HasLenBetween(0, 1),
+	got:                   42,
+	expectedCheckFailure:  "expected a type with a length, got int instead\n",
+	expectedNegateFailure: "expected a type with a length, got int instead\n",
+}, {
+	about:   "HasLineCount: lines with trailing newline",
+	checker: qt.HasLineCount(2),
+	got:     "these are\nthe voyages\n",
+	expectedNegateFailure: "the provided value has the given number of lines (2), but should not:\n(lines)\n\t1: \"these are\"\n\t2: \"the voyages\"\n",
+}, {
+	about:   "HasLineCount: bytes without trailing newline",
+	checker: qt.HasLineCount(3),
+	got:     []byte("a\n\nb"),
+	expectedNegateFailure: "the provided value has the given number of lines (3), but should not:\n(lines)\n\t1: \"a\"\n\t2: \"\"\n\t3: \"b\"\n",
+}, {
+	about:   "HasLineCount: empty string",
+	checker: qt.HasLineCount(0),
+	got:     "",
+	expectedNegateFailure: "the provided value has the given number of lines (0), but should not:\n(lines)\n\t<none>\n",
+}, {
+	about:                "HasLineCount: mismatch",
+	checker:              qt.HasLineCount(1),
+	got:                  "bad\nwolf\n",
+	expectedCheckFailure: "unexpected number of lines: got 2, want 1:\n(lines)\n\t1: \"bad\"\n\t2: \"wolf\"\n",
+}, {
+	about:                "HasLineCount: only a newline",
+	checker:              qt.HasLineCount(1),
+	got:                  "\n",
+	expectedCheckFailure: "unexpected number of lines: got 0, want 1:\n(lines)\n\t<none>\n",
+}, {
+	about:                 "HasLineCount: invalid count",
+	checker:               qt.HasLineCount(-1),
+	got:                   "",
+	expectedCheckFailure:  "invalid number of lines -1\n",
+	expectedNegateFailure: "invalid number of lines -1\n",
+}, {
+	about:                 "HasLineCount: not a string",
+	checker:               qt.HasLineCount(1),
+	got:                   42,
+	expectedCheckFailure:  "expected a string or a []byte, got int instead\n",
+	expectedNegateFailure: "expected a string or a []byte, got int instead\n",
+}, {
+	about:   "HasLineCountRaw: trailing newline",
+	checker: qt.HasLineCountRaw(3),
+	got:     "bad\nwolf\n",
+	expectedNegateFailure: "the provided value has the given number of lines (3), but should not:\n(lines)\n\t1: \"bad\"\n\t2: \"wolf\"\n\t3: \"\"\n",
+}, {
+	about:                "HasLineCountRaw: empty string",
+	checker:              qt.HasLineCountRaw(0),
+	got:                  "",
+	expectedCheckFailure: "unexpected number of lines: got 1, want 0:\n(lines)\n\t1: \"\"\n",
+}, {
+	about:   "HasKeys: same keys",
+	checker: qt.HasKeys("answer", "name"),
+	got:     map[string]interface{}{"name": "bad wolf", "answer": 42},
+	expectedNegateFailure: "map has exactly the given keys, but should not:\n(keys)\n\t\"answer\"\n\t\"name\"\n",
+}, {
+	about:   "HasKeys: empty map",
+	checker: qt.HasKeys(),
+	got:     map[int]bool{},
+	expectedNegateFailure: "map has exactly the given keys, but should not:\n(keys)\n",
+}, {
+	about:                "HasKeys: missing and extra keys",
+	checker:              qt.HasKeys("name", "answer", "name"),
+	got:                  map[string]int{"name": 1, "who": 2, "dalek": 3},
+	expectedCheckFailure: "map does not have the expected keys:\n(missing)\n\t\"answer\"\n(extra)\n\t\"dalek\"\n\t\"who\"\n",
+}, {
+	about:                 "HasKeys: key not assignable",
+	checker:               qt.HasKeys(42),
+	got:                   map[int64]int{42: 1},
+	expectedCheckFailure:  "key 42 of type int is not assignable to the map key type int64\n",
+	expectedNegateFailure: "key 42 of type int is not assignable to the map key type int64\n",
+}, {
+	about:                 "HasKeys: key not comparable",
+	checker:               qt.HasKeys([]int{42}),
+	got:                   map[interface{}]int{42: 1},
+	expectedCheckFailure:  "key []int{42} of type []int is not comparable\n",
+	expectedNegateFailure: "key []int{42} of type []int is not comparable\n",
+}, {
+	about:                 "HasKeys: not a map",
+	checker:               qt.HasKeys("name"),
+	got:                   []string{"name"},
+	expectedCheckFailure:  "expected a map, got []string instead\n",
+	expectedNegateFailure: "expected a map, got []string instead\n",
+}, {
+	about:   "HasKeysSubset: subset of keys",
+	checker: qt.HasKeysSubset("name"),
+	got:     map[string]int{"name": 1, "answer": 42},
+	expectedNegateFailure: "map has all the given keys, but should not:\n(keys)\n\t\"name\"\n",
+}, {
+	about:   "HasKeysSubset: interface keys",
+	checker: qt.HasKeysSubset(42, "answer"),
+	got:     map[interface{}]bool{42: true, "answer": true, 47: false},
+	expectedNegateFailure: "map has all the given keys, but should not:\n(keys)\n\t42\n\t\"answer\"\n",
+}, {
+	about:                "HasKeysSubset: missing keys",
+	checker:              qt.HasKeysSubset("name", "answer"),
+	got:                  map[string]int{"name": 1, "who": 2},
+	expectedCheckFailure: "map does not have all the expected keys:\n(missing)\n\t\"answer\"\n",
+}, {
+	about:   "MapSlicesUnorderedEqual: same elements in different order",
+	checker: qt.MapSlicesUnorderedEqual,
+	got:     map[string][]string{"Accept": {"text/html", "application/json"}, "X-Who": {"bad wolf"}},
+	args:    []interface{}{map[string][]string{"Accept": {"application/json", "text/html"}, "X-Who": {"bad wolf"}}},
+	expectedNegateFailure: "maps have the same values as sets, but should not:\n(got)\n\t",
+}, {
+	about:   "MapSlicesUnorderedEqual: duplicates and different map types",
+	checker: qt.MapSlicesUnorderedEqual,
+	got:     http.Header{"Accept": {"text/html", "text/html"}},
+	args:    []interface{}{map[string][]string{"Accept": {"text/html"}}},
+	expectedNegateFailure: "maps have the same values as sets, but should not:\n(got)\n\thttp.Header{\"Accept\":[]string{\"text/html\", \"text/html\"}}\n(want)\n\tmap[string][]string{\"Accept\":[]string{\"text/html\"}}\n",
+}, {
+	about:                "MapSlicesUnorderedEqual: different elements",
+	checker:              qt.MapSlicesUnorderedEqual,
+	got:                  map[string][]string{"Accept": {"text/html", "text/plain"}, "X-Who": {"bad wolf"}},
+	args:                 []interface{}{map[string][]string{"Accept": {"application/json", "text/html"}, "X-Who": {"good wolf"}}},
+	expectedCheckFailure: "values are not equal as sets for key \"Accept\":\n(missing)\n\t\"application/json\"\n(extra)\n\t\"text/plain\"\n",
+}, {
+	about:                "MapSlicesUnorderedEqual: missing key",
+	checker:              qt.MapSlicesUnorderedEqual,
+	got:                  map[string][]string{},
+	args:                 []interface{}{map[string][]string{"Accept": {"text/html"}}},
+	expectedCheckFailure: "key \"Accept\" not found in the provided map:\n(want)\n\t[]string{\"text/html\"}\n",
+}, {
+	about:                "MapSlicesUnorderedEqual: unexpected key",
+	checker:              qt.MapSlicesUnorderedEqual,
+	got:                  map[string][]string{"Accept": {"text/html"}},
+	args:                 []interface{}{map[string][]string{}},
+	expectedCheckFailure: "unexpected key \"Accept\" found in the provided map:\n(got)\n\t[]string{\"text/html\"}\n",
+}, {
+	about:                 "MapSlicesUnorderedEqual: not a map of slices",
+	checker:               qt.MapSlicesUnorderedEqual,
+	got:                   map[string]string{},
+	args:                  []interface{}{map[string][]string{}},
+	expectedCheckFailure:  "expected a map of slices, got map[string]string instead\n",
+	expectedNegateFailure: "expected a map of slices, got map[string]string instead\n",
+}, {
+	about:                 "MapSlicesUnorderedEqual: invalid expected value",
+	checker:               qt.MapSlicesUnorderedEqual,
+	got:                   map[string][]string{},
+	args:                  []interface{}{[]string{}},
+	expectedCheckFailure:  "expected value is of type []string, not a map of slices\n",
+	expectedNegateFailure: "expected value is of type []string, not a map of slices\n",
+}, {
+	about:                 "MapSlicesUnorderedEqual: incompatible keys",
+	checker:               qt.MapSlicesUnorderedEqual,
+	got:                   map[string][]string{},
+	args:                  []interface{}{map[bool][]string{}},
+	expectedCheckFailure:  "cannot compare map keys of type string with map keys of type bool\n",
+	expectedNegateFailure: "cannot compare map keys of type string with map keys of type bool\n",
+}, {
+	about:   "MapSlicesUnorderedEqual: elements with unexported fields",
+	checker: qt.MapSlicesUnorderedEqual,
+	got:     map[string][]companion{"tardis": {{Name: "Rose", secret: "bad wolf"}}},
+	args:    []interface{}{map[string][]companion{"tardis": {{Name: "Rose", secret: "bad wolf"}}}},
+	expectedNegateFailure: "maps have the same values as sets, but should not:\n",
+}, {
+	about:   "Between: value in range",
+	checker: qt.Between(0, 100),
+	got:     42,
+	expectedNegateFailure: "value is in the range [0, 100], but should not:\n(value)\n\t42\n",
+}, {
+	about:   "Between: value equal to bound",
+	checker: qt.Between(0, 100),
+	got:     uint8(100),
+	expectedNegateFailure: "value is in the range [0, 100], but should not:\n(value)\n\t0x64\n",
+}, {
+	about:   "Between: mixed numeric types",
+	checker: qt.Between(int64(-1), 0.5),
+	got:     float32(0.25),
+	expectedNegateFailure: "value is in the range [-1, 0.5], but should not:\n(value)\n\t0.25\n",
+}, {
+	about:   "Between: durations",
+	checker: qt.Between(0, time.Second),
+	got:     500 * time.Millisecond,
+	expectedNegateFailure: "value is in the range [0, 1s], but should not:\n(value)\n\t500000000\n",
+}, {
+	about:                "Between: value out of range",
+	checker:              qt.Between(0, 100),
+	got:                  -1,
+	expectedCheckFailure: "value is not in the range [0, 100]:\n(value)\n\t-1\n",
+}, {
+	about:                "Between: NaN",
+	checker:              qt.Between(0, 1),
+	got:                  math.NaN(),
+	expectedCheckFailure: "value is not in the range [0, 1]:\n(value)\n\tNaN\n",
+}, {
+	about:                 "Between: value not a number",
+	checker:               qt.Between(0, 100),
+	got:                   "42",
+	expectedCheckFailure:  "expected a numeric value, got string instead\n",
+	expectedNegateFailure: "expected a numeric value, got string instead\n",
+}, {
+	about:                 "Between: bounds not numbers",
+	checker:               qt.Between("a", 100),
+	got:                   42,
+	expectedCheckFailure:  "range bounds must be numeric, got string and int instead\n",
+	expectedNegateFailure: "range bounds must be numeric, got string and int instead\n",
+}, {
+	about:                 "Between: invalid range",
+	checker:               qt.Between(100, 0),
+	got:                   42,
+	expectedCheckFailure:  "invalid range [100, 0]: min is greater than max\n",
+	expectedNegateFailure: "invalid range [100, 0]: min is greater than max\n",
+}, {
+	about:   "BetweenExclusive: value in range",
+	checker: qt.BetweenExclusive(0, 1),
+	got:     0.5,
+	expectedNegateFailure: "value is in the range (0, 1), but should not:\n(value)\n\t0.5\n",
+}, {
+	about:                "BetweenExclusive: value equal to bound",
+	checker:              qt.BetweenExclusive(0, 1),
+	got:                  0.0,
+	expectedCheckFailure: "value is not in the range (0, 1):\n(value)\n\t0\n",
+}, {
+	about:   "InBucket: value in one bucket",
+	checker: qt.InBucket(map[string][2]float64{"slow": {1, math.Inf(1)}, "fast": {0, 0.1}, "medium": {0.1, 1}}),
+	got:     0.5,
+	expectedNegateFailure: "value is in bucket \"medium\", but should not:\n(value)\n\t0.5\n(buckets)\n\t\"fast\": [0, 0.1]\n\t\"medium\": [0.1, 1]\n\t\"slow\": [1, +Inf]\n",
+}, {
+	about:   "InBucket: integer value in unbounded bucket",
+	checker: qt.InBucket(map[string][2]float64{"slow": {1, math.Inf(1)}, "fast": {0, 0.1}, "medium": {0.1, 1}}),
+	got:     42,
+	expectedNegateFailure: "value is in bucket \"slow\", but should not:\n(value)\n\t42\n(buckets)\n\t\"fast\": [0, 0.1]\n\t\"medium\": [0.1, 1]\n\t\"slow\": [1, +Inf]\n",
+}, {
+	about:                "InBucket: value in no buckets",
+	checker:              qt.InBucket(map[string][2]float64{"slow": {1, math.Inf(1)}, "fast": {0, 0.1}, "medium": {0.1, 1}}),
+	got:                  -1,
+	expectedCheckFailure: "value is not in any bucket:\n(value)\n\t-1\n(buckets)\n\t\"fast\": [0, 0.1]\n\t\"medium\": [0.1, 1]\n\t\"slow\": [1, +Inf]\n",
+}, {
+	about:                "InBucket: value in more than one bucket",
+	checker:              qt.InBucket(map[string][2]float64{"slow": {1, math.Inf(1)}, "fast": {0, 0.1}, "medium": {0.1, 1}}),
+	got:                  1,
+	expectedCheckFailure: "value is in more than one bucket:\n(value)\n\t1\n(matching buckets)\n\t\"medium\"\n\t\"slow\"\n(buckets)\n\t\"fast\": [0, 0.1]\n\t\"medium\": [0.1, 1]\n\t\"slow\": [1, +Inf]\n",
+}, {
+	about:                 "InBucket: no buckets",
+	checker:               qt.InBucket(nil),
+	got:                   1,
+	expectedCheckFailure:  "no buckets provided\n",
+	expectedNegateFailure: "no buckets provided\n",
+}, {
+	about:                 "InBucket: invalid bucket",
+	checker:               qt.InBucket(map[string][2]float64{"bad": {1, 0}}),
+	got:                   1,
+	expectedCheckFailure:  "invalid bucket \"bad\" [1, 0]: lo is greater than hi\n",
+	expectedNegateFailure: "invalid bucket \"bad\" [1, 0]: lo is greater than hi\n",
+}, {
+	about:                 "InBucket: not a number",
+	checker:               qt.InBucket(map[string][2]float64{"slow": {1, math.Inf(1)}, "fast": {0, 0.1}, "medium": {0.1, 1}}),
+	got:                   "fast",
+	expectedCheckFailure:  "expected a numeric value, got string instead\n",
+	expectedNegateFailure: "expected a numeric value, got string instead\n",
+}, {
+	about:   "IsPositive: positive int",
+	checker: qt.IsPositive,
+	got:     42,
+	expectedNegateFailure: "value is positive, but should not:\n(value)\n\t42\n",
+}, {
+	about:   "IsPositive: positive float",
+	checker: qt.IsPositive,
+	got:     float32(0.5),
+	expectedNegateFailure: "value is positive, but should not:\n(value)\n\t0.5\n",
+}, {
+	about:                "IsPositive: zero",
+	checker:              qt.IsPositive,
+	got:                  uint(0),
+	expectedCheckFailure: "value is not positive:\n(value)\n\t0\n",
+}, {
+	about:                "IsPositive: NaN",
+	checker:              qt.IsPositive,
+	got:                  math.NaN(),
+	expectedCheckFailure: "value is not positive:\n(value)\n\tNaN\n",
+}, {
+	about:                 "IsPositive: not a number",
+	checker:               qt.IsPositive,
+	got:                   "42",
+	expectedCheckFailure:  "expected a numeric value, got string instead\n",
+	expectedNegateFailure: "expected a numeric value, got string instead\n",
+}, {
+	about:   "IsNegative: negative int",
+	checker: qt.IsNegative,
+	got:     int8(-1),
+	expectedNegateFailure: "value is negative, but should not:\n(value)\n\t-1\n",
+}, {
+	about:   "IsNegative: negative infinity",
+	checker: qt.IsNegative,
+	got:     math.Inf(-1),
+	expectedNegateFailure: "value is negative, but should not:\n(value)\n\t-Inf\n",
+}, {
+	about:                "IsNegative: unsigned integer",
+	checker:              qt.IsNegative,
+	got:                  uint64(math.MaxUint64),
+	expectedCheckFailure: "value is not negative:\n(value)\n\t18446744073709551615\n",
+}, {
+	about:                "IsNegative: zero",
+	checker:              qt.IsNegative,
+	got:                  0.0,
+	expectedCheckFailure: "value is not negative:\n(value)\n\t0\n",
+}, {
+	about:   "IsNonZero: negative number",
+	checker: qt.IsNonZero,
+	got:     -0.1,
+	expectedNegateFailure: "value is non-zero, but should not:\n(value)\n\t-0.1\n",
+}, {
+	about:   "IsNonZero: NaN",
+	checker: qt.IsNonZero,
+	got:     math.NaN(),
+	expectedNegateFailure: "value is non-zero, but should not:\n(value)\n\tNaN\n",
+}, {
+	about:                "IsNonZero: zero",
+	checker:              qt.IsNonZero,
+	got:                  int64(0),
+	expectedCheckFailure: "value is zero:\n(value)\n\t0\n",
+}, {
+	about:                 "IsNonZero: not a number",
+	checker:               qt.IsNonZero,
+	got:                   nil,
+	expectedCheckFailure:  "expected a numeric value, got <nil> instead\n",
+	expectedNegateFailure: "expected a numeric value, got <nil> instead\n",
+}, {
+	about:   "IsFinite: finite float64",
+	checker: qt.IsFinite,
+	got:     -1.5,
+	expectedNegateFailure: "the provided value is finite, but should not:\n(value)\n\t-1.5\n",
+}, {
+	about:   "IsFinite: finite float32",
+	checker: qt.IsFinite,
+	got:     float32(math.MaxFloat32),
+	expectedNegateFailure: "the provided value is finite, but should not:\n(value)\n\t3.4028235e+38\n",
+}, {
+	about:                "IsFinite: NaN",
+	checker:              qt.IsFinite,
+	got:                  math.NaN(),
+	expectedCheckFailure: "the provided value is not finite:\n(value)\n\tNaN\n",
+}, {
+	about:                "IsFinite: positive infinity",
+	checker:              qt.IsFinite,
+	got:                  math.Inf(1),
+	expectedCheckFailure: "the provided value is not finite:\n(value)\n\t+Inf\n",
+}, {
+	about:                "IsFinite: negative infinity float32",
+	checker:              qt.IsFinite,
+	got:                  float32(math.Inf(-1)),
+	expectedCheckFailure: "the provided value is not finite:\n(value)\n\t-Inf\n",
+}, {
+	about:                 "IsFinite: not a float",
+	checker:               qt.IsFinite,
+	got:                   42,
+	expectedCheckFailure:  "expected a floating point value, got int instead\n",
+	expectedNegateFailure: "expected a floating point value, got int instead\n",
+}, {
+	about:   "IsSorted: sorted ints",
+	checker: qt.IsSorted,
+	got:     []int{1, 1, 2, 42},
+	expectedNegateFailure: "the provided value is sorted, but should not:\n(value)\n\t[]int{1, 1, 2, 42}\n",
+}, {
+	about:   "IsSorted: sorted strings array",
+	checker: qt.IsSorted,
+	got:     [3]string{"dalek", "voyages", "wolf"},
+	expectedNegateFailure: "the provided value is sorted, but should not:\n(value)\n\t[3]string{\"dalek\", \"voyages\", \"wolf\"}\n",
+}, {
+	about:   "IsSorted: empty slice",
+	checker: qt.IsSorted,
+	got:     []float64{},
+	expectedNegateFailure: "the provided value is sorted, but should not:\n(value)\n\t[]float64{}\n",
+}, {
+	about:   "IsSorted: single element",
+	checker: qt.IsSorted,
+	got:     []uint{47},
+	expectedNegateFailure: "the provided value is sorted, but should not:\n(value)\n\t[]uint{0x2f}\n",
+}, {
+	about:                 "IsSorted: unordered element type",
+	checker:               qt.IsSorted,
+	got:                   []struct{}{{}},
+	expectedCheckFailure:  "cannot sort elements of type struct {} without a less function\n",
+	expectedNegateFailure: "cannot sort elements of type struct {} without a less function\n",
+}, {
+	about:                "IsSorted: not sorted",
+	checker:              qt.IsSorted,
+	got:                  []int{1, 2, 42, 47, 3},
+	expectedCheckFailure: "the provided value is not sorted: element 4 is less than element 3:\n(value)\n\t[]int{1, 2, 42, 47, 3}\n(element 3)\n\t47\n(element 4)\n\t3\n",
+}, {
+	about:                 "IsSorted: not a slice",
+	checker:               qt.IsSorted,
+	got:                   "bad wolf",
+	expectedCheckFailure:  "expected a slice or an array, got string instead\n",
+	expectedNegateFailure: "expected a slice or an array, got string instead\n",
+}, {
+	about:   "IsSortedBy: sorted",
+	checker: qt.IsSortedBy(func(a, b []int) bool { return len(a) < len(b) }),
+	got:     [][]int{{}, {42}, {42, 47}},
+	expectedNegateFailure: "the provided value is sorted, but should not:\n(value)\n\t[][]int{[]int{}, []int{42}, []int{42, 47}}\n",
+}, {
+	about:                "IsSortedBy: not sorted",
+	checker:              qt.IsSortedBy(func(a, b string) bool { return a > b }),
+	got:                  []string{"c", "b", "x"},
+	expectedCheckFailure: "the provided value is not sorted: element 2 is less than element 1:\n(value)\n\t[]string{\"c\", \"b\", \"x\"}\n(element 1)\n\t\"b\"\n(element 2)\n\t\"x\"\n",
+}, {
+	about:                 "IsSortedBy: invalid less function",
+	checker:               qt.IsSortedBy(func(a int) bool { return true }),
+	got:                   []int{},
+	expectedCheckFailure:  "less function must be of type func(a, b T) bool, got func(int) bool instead\n",
+	expectedNegateFailure: "less function must be of type func(a, b T) bool, got func(int) bool instead\n",
+}, {
+	about:                 "IsSortedBy: nil less function",
+	checker:               qt.IsSortedBy((func(a, b int) bool)(nil)),
+	got:                   []int{1, 2},
+	expectedCheckFailure:  "less function of type func(int, int) bool is nil\n",
+	expectedNegateFailure: "less function of type func(int, int) bool is nil\n",
+}, {
+	about:                 "IsSortedBy: less function with wrong type",
+	checker:               qt.IsSortedBy(func(a, b int) bool { return a < b }),
+	got:                   []string{},
+	expectedCheckFailure:  "less function of type func(int, int) bool cannot compare elements of type string\n",
+	expectedNegateFailure: "less function of type func(int, int) bool cannot compare elements of type string\n",
+}, {
+	about:   "IsUnique: unique ints",
+	checker: qt.IsUnique,
+	got:     []int{1, 2, 42},
+	expectedNegateFailure: "the provided value has unique elements, but should not:\n(value)\n\t[]int{1, 2, 42}\n",
+}, {
+	about:   "IsUnique: empty slice",
+	checker: qt.IsUnique,
+	got:     []string{},
+	expectedNegateFailure: "the provided value has unique elements, but should not:\n(value)\n\t[]string{}\n",
+}, {
+	about:   "IsUnique: unique slices",
+	checker: qt.IsUnique,
+	got:     [][]int{{1, 2}, {2, 1}},
+	expectedNegateFailure: "the provided value has unique elements, but should not:\n(value)\n\t[][]int{[]int{1, 2}, []int{2, 1}}\n",
+}, {
+	about:                "IsUnique: duplicate strings",
+	checker:              qt.IsUnique,
+	got:                  []string{"dalek", "wolf", "voyages", "wolf", "dalek", "wolf"},
+	expectedCheckFailure: "the provided value has duplicate elements:\n(value)\n\t[]string{\"dalek\", \"wolf\", \"voyages\", \"wolf\", \"dalek\", \"wolf\"}\n(duplicate)\n\t\"dalek\"\n(indices)\n\t[0 4]\n",
+}, {
+	about:                "IsUnique: duplicate maps in array",
+	checker:              qt.IsUnique,
+	got:                  [3]map[string]int{{"a": 1}, {"b": 2}, {"b": 2}},
+	expectedCheckFailure: "the provided value has duplicate elements:\n(value)\n\t[3]map[string]int{map[string]int{\"a\":1}, map[string]int{\"b\":2}, map[string]int{\"b\":2}}\n(duplicate)\n\tmap[string]int{\"b\":2}\n(indices)\n\t[1 2]\n",
+}, {
+	about:   "IsUnique: duplicate structs with unexported fields",
+	checker: qt.IsUnique,
+	got: []struct{ answer int }{
+		{answer: 42}, {answer: 47}, {answer: 42},
+	},
+	expectedCheckFailure: "the provided value has duplicate elements:\n(value)\n\t[]struct { answer int }{struct { answer int }{answer:42}, struct { answer int }{answer:47}, struct { answer int }{answer:42}}\n(duplicate)\n\tstruct { answer int }{answer:42}\n(indices)\n\t[0 2]\n",
+}, {
+	about:                 "IsUnique: not a slice",
+	checker:               qt.IsUnique,
+	got:                   map[string]int{"a": 1},
+	expectedCheckFailure:  "expected a slice or an array, got map[string]int instead\n",
+	expectedNegateFailure: "expected a slice or an array, got map[string]int instead\n",
+}, {
+	about: "ContainsMap: subset",
+	checker: qt.ContainsMap(map[string]int{
+		"answer": 42,
+	}),
+	got: map[string]int{
+		"answer":   42,
+		"question": 0,
+	},
+	expectedNegateFailure: "the provided map contains all the expected entries, but should not:\n(value)\n\tmap[string]int{",
+}, {
+	about:   "ContainsMap: empty subset",
+	checker: qt.ContainsMap(map[string][]int{}),
+	got:     map[string][]int{"ints": {42}},
+	expectedNegateFailure: "the provided map contains all the expected entries, but should not:\n(value)\n\tmap[string][]int{\"ints\":[]int{42}}\n",
+}, {
+	about:   "ContainsMap: deeply equal values",
+	checker: qt.ContainsMap(map[string][]int{"ints": {42, 47}}),
+	got:     map[string][]int{"ints": {42, 47}},
+	expectedNegateFailure: "the provided map contains all the expected entries, but should not:\n",
+}, {
+	about:                "ContainsMap: missing key",
+	checker:              qt.ContainsMap(map[string]int{"answer": 42, "question": 0}),
+	got:                  map[string]int{"answer": 42},
+	expectedCheckFailure: "key \"question\" not found in map:\n(value)\n\tmap[string]int{\"answer\":42}\n",
+}, {
+	about:                "ContainsMap: value mismatch",
+	checker:              qt.ContainsMap(map[int]string{1: "bad", 2: "wolf"}),
+	got:                  map[int]string{1: "bad", 2: "dalek", 3: "exterminate"},
+	expectedCheckFailure: "map value mismatch for key 2:\n(-got +want)\n\t-: \"dalek\"\n\t+: \"wolf\"\n",
+}, {
+	about:                 "ContainsMap: different types",
+	checker:               qt.ContainsMap(map[string]string{"answer": "42"}),
+	got:                   map[string]int{"answer": 42},
+	expectedCheckFailure:  "cannot compare map of type map[string]int with subset of type map[string]string\n",
+	expectedNegateFailure: "cannot compare map of type map[string]int with subset of type map[string]string\n",
+}, {
+	about:                 "ContainsMap: not a map",
+	checker:               qt.ContainsMap(map[string]int{}),
+	got:                   []int{42},
+	expectedCheckFailure:  "expected a map, got []int instead\n",
+	expectedNegateFailure: "expected a map, got []int instead\n",
+}, {
+	about:                 "ContainsMap: subset not a map",
+	checker:               qt.ContainsMap(42),
+	got:                   map[string]int{},
+	expectedCheckFailure:  "expected subset is not a map, got int instead\n",
+	expectedNegateFailure: "expected subset is not a map, got int instead\n",
+}, {
+	about:   "ContainsInOrder: subsequence",
+	checker: qt.ContainsInOrder("connect", "login", "logout"),
+	got:     []string{"connect", "ping", "login", "ping", "logout"},
+	expectedNegateFailure: "the provided value contains the elements in order, but should not:\n(elements)\n\t\"connect\"\n\t\"login\"\n\t\"logout\"\n(value)\n\t[]string{\"connect\", \"ping\", \"login\", \"ping\", \"logout\"}\n",
+}, {
+	about:   "ContainsInOrder: repeated elements",
+	checker: qt.ContainsInOrder(42, 42),
+	got:     [3]int{42, 47, 42},
+	expectedNegateFailure: "the provided value contains the elements in order, but should not:\n",
+}, {
+	about:   "ContainsInOrder: no elements",
+	checker: qt.ContainsInOrder(),
+	got:     []int{},
+	expectedNegateFailure: "the provided value contains the elements in order, but should not:\n",
+}, {
+	about:   "ContainsInOrder: deeply equal elements",
+	checker: qt.ContainsInOrder([]int{47}),
+	got:     [][]int{{42}, {47}},
+	expectedNegateFailure: "the provided value contains the elements in order, but should not:\n",
+}, {
+	about:                "ContainsInOrder: elements out of order",
+	checker:              qt.ContainsInOrder("connect", "logout", "login"),
+	got:                  []string{"connect", "login", "logout"},
+	expectedCheckFailure: "element \"login\" not found after element \"logout\" at index 2:\n(value)\n\t[]string{\"connect\", \"login\", \"logout\"}\n",
+}, {
+	about:                "ContainsInOrder: repeated element missing",
+	checker:              qt.ContainsInOrder(42, 42),
+	got:                  []int{42, 47},
+	expectedCheckFailure: "element 42 not found after element 42 at index 0:\n(value)\n\t[]int{42, 47}\n",
+}, {
+	about:                "ContainsInOrder: first element missing",
+	checker:              qt.ContainsInOrder("disconnect"),
+	got:                  []string{"connect"},
+	expectedCheckFailure: "element \"disconnect\" not found:\n(value)\n\t[]string{\"connect\"}\n",
+}, {
+	about:                 "ContainsInOrder: not a slice",
+	checker:               qt.ContainsInOrder("connect"),
+	got:                   "connect",
+	expectedCheckFailure:  "expected a slice or an array, got string instead\n",
+	expectedNegateFailure: "expected a slice or an array, got string instead\n",
+}, {
+	about:   "ContainsInOrder: elements with unexported fields",
+	checker: qt.ContainsInOrder(companion{Name: "Rose", secret: "bad wolf"}),
+	got:     []companion{{Name: "Donna"}, {Name: "Rose", secret: "bad wolf"}},
+	expectedNegateFailure: "the provided value contains the elements in order, but should not:\n",
+}, {
+	about:   "ContainsMatch: match",
+	checker: qt.ContainsMatch(qt.Equals, 42),
+	got:     []int{1, 42, 3, 42},
+	expectedNegateFailure: "element 1 matches the checker, but should not:\n(value)\n\t[]int{1, 42, 3, 42}\n(element)\n\t42\n",
+}, {
+	about:   "ContainsMatch: match in array with pointer",
+	checker: qt.ContainsMatch(qt.Matches, "bad.*"),
+	got:     [2]string{"these are the voyages", "bad wolf"},
+	args:    []interface{}{new(string)},
+	expectedNegateFailure: "element 1 matches the checker, but should not:\n(value)\n\t[2]string{\"these are the voyages\", \"bad wolf\"}\n(element)\n\t\"bad wolf\"\n",
+}, {
+	about:                "ContainsMatch: no match",
+	checker:              qt.ContainsMatch(qt.Equals, 42),
+	got:                  []int{1, 2},
+	expectedCheckFailure: "no element matches the checker:\n(value)\n\t[]int{1, 2}\n(failures)\n\telement 0:\n\t\tnot equal:\n\t\t(-got +want)\n\t\t\t-: 1\n\t\t\t+: 42\n\telement 1:\n\t\tnot equal:\n\t\t(-got +want)\n\t\t\t-: 2\n\t\t\t+: 42\n",
+}, {
+	about:                "ContainsMatch: empty slice",
+	checker:              qt.ContainsMatch(qt.IsNil),
+	got:                  []error{},
+	expectedCheckFailure: "no element matches the checker:\n(value)\n\t[]error{}\n",
+}, {
+	about:                 "ContainsMatch: not a slice",
+	checker:               qt.ContainsMatch(qt.Equals, 42),
+	got:                   42,
+	expectedCheckFailure:  "expected a slice or an array, got int instead\n",
+	expectedNegateFailure: "expected a slice or an array, got int instead\n",
+}, {
+	about:                 "ContainsMatch: bad check",
+	checker:               qt.ContainsMatch(qt.Matches, 42),
+	got:                   []string{"bad wolf"},
+	expectedCheckFailure:  "the regular expression pattern must be a string, got int instead\n",
+	expectedNegateFailure: "the regular expression pattern must be a string, got int instead\n",
+}, {
+	about:                 "ContainsMatch: invalid number of checker arguments",
+	checker:               qt.ContainsMatch(qt.Equals),
+	got:                   []int{42},
+	expectedCheckFailure:  "invalid number of arguments provided to the checker: got 0, want 1\n",
+	expectedNegateFailure: "invalid number of arguments provided to the checker: got 0, want 1\n",
+}, {
+	about:                 "ContainsMatch: nil checker",
+	checker:               qt.ContainsMatch(nil),
+	got:                   []int{42},
+	expectedCheckFailure:  "nil checker provided\n",
+	expectedNegateFailure: "nil checker provided\n",
+}, {
+	about:                 "ContainsMatch: not a pointer",
+	checker:               qt.ContainsMatch(qt.Equals, 42),
+	got:                   []int{42},
+	args:                  []interface{}{42},
+	expectedCheckFailure:  "expected a non-nil pointer to store the matching element, got int instead\n",
+	expectedNegateFailure: "expected a non-nil pointer to store the matching element, got int instead\n",
+}, {
+	about:                 "ContainsMatch: pointer of the wrong type",
+	checker:               qt.ContainsMatch(qt.Equals, 42),
+	got:                   []int{42},
+	args:                  []interface{}{new(string)},
+	expectedCheckFailure:  "cannot store an element of type int into *string\n",
+	expectedNegateFailure: "cannot store an element of type int into *string\n",
+}, {
+	about:                 "ContainsMatch: too many arguments",
+	checker:               qt.ContainsMatch(qt.Equals, 42),
+	got:                   []int{42},
+	args:                  []interface{}{new(int), new(int)},
+	expectedCheckFailure:  "too many arguments provided to checker: got 2, want at most 1\n",
+	expectedNegateFailure: "too many arguments provided to checker: got 2, want at most 1\n",
+}, {
+	about:   "OneOf: match",
+	checker: qt.OneOf(200, 204),
+	got:     204,
+	expectedNegateFailure: "value is one of the given options, but should not:\n(value)\n\t204\n(matching option)\n\t1: 204\n",
+}, {
+	about:   "OneOf: deep equality",
+	checker: qt.OneOf([]string{"bad"}, []string{"bad", "wolf"}),
+	got:     []string{"bad", "wolf"},
+	expectedNegateFailure: "value is one of the given options, but should not:\n(value)\n\t[]string{\"bad\", \"wolf\"}\n(matching option)\n\t1: []string{\"bad\", \"wolf\"}\n",
+}, {
+	about:                "OneOf: no match",
+	checker:              qt.OneOf("red", "green"),
+	got:                  "blue",
+	expectedCheckFailure: "value is not one of the expected options:\n(value)\n\t\"blue\"\n(options)\n\t\"red\"\n\t\"green\"\n",
+}, {
+	about:                "OneOf: different types",
+	checker:              qt.OneOf(int64(42)),
+	got:                  42,
+	expectedCheckFailure: "value is not one of the expected options:\n(value)\n\t42\n(options)\n\t42\n",
+}, {
+	about:                 "OneOf: no options",
+	checker:               qt.OneOf(),
+	got:                   42,
+	expectedCheckFailure:  "no options provided\n",
+	expectedNegateFailure: "no options provided\n",
+}, {
+	about:   "OneOf: option with unexported fields",
+	checker: qt.OneOf(companion{Name: "Donna"}, companion{Name: "Rose", secret: "bad wolf"}),
+	got:     companion{Name: "Rose", secret: "bad wolf"},
+	expectedNegateFailure: "value is one of the given options, but should not:\n",
+}, {
+	about:   "PrefixEquals: success",
+	checker: qt.PrefixEquals([]string{"first", "second"}),
+	got:     []string{"first", "second", "third"},
+	expectedNegateFailure: "the provided value starts with the given elements, but should not:\n(prefix)\n\t[]string{\"first\", \"second\"}\n(value)\n\t[]string{\"first\", \"second\", \"third\"}\n",
+}, {
+	about:   "PrefixEquals: array",
+	checker: qt.PrefixEquals([]int{42}),
+	got:     [2]int{42, 47},
+	expectedNegateFailure: "the provided value starts with the given elements, but should not:\n(prefix)\n\t[]int{42}\n(value)\n\t[2]int{42, 47}\n",
+}, {
+	about:   "PrefixEquals: empty prefix",
+	checker: qt.PrefixEquals([]int{}),
+	got:     []int(nil),
+	expectedNegateFailure: "the provided value starts with the given elements, but should not:\n(prefix)\n\t[]int{}\n(value)\n\t[]int(nil)\n",
+}, {
+	about:                "PrefixEquals: mismatch",
+	checker:              qt.PrefixEquals([]string{"first", "second"}),
+	got:                  []string{"first", "other", "second"},
+	expectedCheckFailure: "values are not equal in the first 2 elements:\n(-got +want)\n",
+}, {
+	about:                "PrefixEquals: too short",
+	checker:              qt.PrefixEquals([]string{"first", "second"}),
+	got:                  []string{"first"},
+	expectedCheckFailure: "value is shorter than the expected prefix:\n(length)\n\t1\n(prefix length)\n\t2\n(value)\n\t[]string{\"first\"}\n",
+}, {
+	about:                 "PrefixEquals: not a slice",
+	checker:               qt.PrefixEquals([]string{"first"}),
+	got:                   "first",
+	expectedCheckFailure:  "expected a slice or an array, got string instead\n",
+	expectedNegateFailure: "expected a slice or an array, got string instead\n",
+}, {
+	about:                 "PrefixEquals: invalid prefix",
+	checker:               qt.PrefixEquals("first"),
+	got:                   []string{"first"},
+	expectedCheckFailure:  "expected prefix must be a slice, got string instead\n",
+	expectedNegateFailure: "expected prefix must be a slice, got string instead\n",
+}, {
+	about:   "PrefixEquals: elements with unexported fields",
+	checker: qt.PrefixEquals([]companion{{Name: "Rose", secret: "bad wolf"}}),
+	got:     []companion{{Name: "Rose", secret: "bad wolf"}, {Name: "Donna"}},
+	expectedNegateFailure: "the provided value starts with the given elements, but should not:\n",
+}, {
+	about:                "PrefixEquals: mismatch with unexported fields",
+	checker:              qt.PrefixEquals([]companion{{Name: "Rose", secret: "bad wolf"}}),
+	got:                  []companion{{Name: "Rose", secret: "time lord"}},
+	expectedCheckFailure: "values are not equal in the first 1 elements:\n(got)\n\t[]quicktest_test.companion{quicktest_test.companion{Name:\"Rose\", Age:0, Tags:[]string(nil), secret:\"time lord\"}}\n(want)\n\t[]quicktest_test.companion{quicktest_test.companion{Name:\"Rose\", Age:0, Tags:[]string(nil), secret:\"bad wolf\"}}\n",
+}, {
+	about:   "Via: success",
+	checker: qt.Via(strings.ToUpper, qt.Equals),
+	got:     "bad wolf",
+	args:    []interface{}{"BAD WOLF"},
+	expectedNegateFailure: "both values equal \"BAD WOLF\", but should not\n(transformed by func(string) string)\n\t\"BAD WOLF\"\n",
+}, {
+	about: "Via: failure",
+	checker: qt.Via(func(ints []int) int {
+		return len(ints)
+	}, qt.Equals),
+	got:                  []int{42, 47},
+	args:                 []interface{}{3},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: 2\n\t+: 3\n(transformed by func([]int) int)\n\t2\n",
+}, {
+	about:   "Via: nil value",
+	checker: qt.Via(func(err error) bool { return err == nil }, qt.Equals),
+	got:     nil,
+	args:    []interface{}{true},
+	expectedNegateFailure: "both values equal true, but should not\n",
+}, {
+	about:                 "Via: invalid transform",
+	checker:               qt.Via(func(a, b int) int { return a + b }, qt.Equals),
+	got:                   42,
+	args:                  []interface{}{42},
+	expectedCheckFailure:  "transform must be a function accepting and returning a single value, got func(int, int) int instead\n",
+	expectedNegateFailure: "transform must be a function accepting and returning a single value, got func(int, int) int instead\n",
+}, {
+	about:                 "Via: wrong argument type",
+	checker:               qt.Via(strings.ToUpper, qt.Equals),
+	got:                   42,
+	args:                  []interface{}{"42"},
+	expectedCheckFailure:  "cannot use value of type int as transform argument of type string\n",
+	expectedNegateFailure: "cannot use value of type int as transform argument of type string\n",
+}, {
+	about:                 "Via: bad check from the inner checker",
+	checker:               qt.Via(strings.ToUpper, qt.Matches),
+	got:                   "bad wolf",
+	args:                  []interface{}{42},
+	expectedCheckFailure:  "the regular expression pattern must be a string, got int instead\n",
+	expectedNegateFailure: "the regular expression pattern must be a string, got int instead\n",
+}, {
+	about:                 "Via: not enough arguments",
+	checker:               qt.Via(strings.ToUpper, qt.Equals),
+	got:                   "bad wolf",
+	expectedCheckFailure:  "not enough arguments provided to checker: got 0, want 1\n",
+	expectedNegateFailure: "not enough arguments provided to checker: got 0, want 1\n",
+}, {
+	about:   "WithTimeout: success",
+	checker: qt.WithTimeout(time.Minute, qt.Equals),
+	got:     42,
+	args:    []interface{}{42},
+	expectedNegateFailure: "both values equal 42, but should not\n",
+}, {
+	about:                "WithTimeout: failure",
+	checker:              qt.WithTimeout(time.Minute, qt.Equals),
+	got:                  42,
+	args:                 []interface{}{47},
+	expectedCheckFailure: "not equal:\n(-got +want)\n\t-: 42\n\t+: 47\n",
+}, {
+	about:   "Via: call options",
+	checker: qt.Via(strings.Fields, qt.DeepEquals),
+	got:     "bad wolf",
+	args:    []interface{}{[]string{"wolf", "bad"}, cmpopts.SortSlices(func(a, b string) bool { return a < b })},
+	expectedNegateFailure: "both values deeply equal []string{\"bad\", \"wolf\"}, but should not\n(transformed by func(string) []string)\n",
+}, {
+	about:   "WithTimeout: call options",
+	checker: qt.WithTimeout(time.Minute, qt.DeepEquals),
+	got:     []int{1, 2},
+	args:    []interface{}{[]int{2, 1}, sameInts},
+	expectedNegateFailure: "both values deeply equal []int{1, 2}, but should not\n",
+}, {
+	about:   "WithTimeout: timeout",
+	checker: qt.WithTimeout(time.Millisecond, qt.CompletesWithin(time.Minute)),
+	got: func() {
+		time.Sleep(100 * time.Millisecond)
+	},
+	expectedCheckFailure:  "checker did not complete within 1ms\n",
+	expectedNegateFailure: "checker did not complete within 1ms\n",
+}, {
+	about:                 "WithTimeout: bad check",
+	checker:               qt.WithTimeout(time.Minute, qt.ErrorMatches),
+	got:                   42,
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "did not get an error, got int instead\n",
+	expectedNegateFailure: "did not get an error, got int instead\n",
 }, {
 	about:   "Not: success",
 	checker: qt.Not(qt.IsNil),
@@ -551,6 +3119,34 @@ var checkerTests = []struct {
 	args:                  []interface{}{42, nil},
 	expectedCheckFailure:  "too many arguments provided to checker: got 2, want 1: unexpected <nil>\n",
 	expectedNegateFailure: "too many arguments provided to checker: got 2, want 1: unexpected <nil>\n",
+}, {
+	about:                 "Not: bad check",
+	checker:               qt.Not(qt.ErrorMatches),
+	got:                   42,
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "did not get an error, got int instead\n",
+	expectedNegateFailure: "did not get an error, got int instead\n",
+}, {
+	about:                 "Not: double negation bad check",
+	checker:               qt.Not(qt.Not(qt.ErrorMatches)),
+	got:                   42,
+	args:                  []interface{}{".*"},
+	expectedCheckFailure:  "did not get an error, got int instead\n",
+	expectedNegateFailure: "did not get an error, got int instead\n",
+}, {
+	about:                 "Not: bad check from transformed value",
+	checker:               qt.Not(qt.Via(strings.ToUpper, qt.Equals)),
+	got:                   42,
+	args:                  []interface{}{"BAD WOLF"},
+	expectedCheckFailure:  "cannot use value of type int as transform argument of type string\n",
+	expectedNegateFailure: "cannot use value of type int as transform argument of type string\n",
+}, {
+	about:                 "Not: bad check from checker with options",
+	checker:               qt.Not(qt.CmpEqualsWithComparer(42)),
+	got:                   42,
+	args:                  []interface{}{42},
+	expectedCheckFailure:  "invalid comparer: expected a func(x, y T) bool, got int instead\n",
+	expectedNegateFailure: "invalid comparer: expected a func(x, y T) bool, got int instead\n",
 }}
 
 func TestCheckers(t *testing.T) {
@@ -569,3 +3165,387 @@ func TestCheckers(t *testing.T) {
 		})
 	}
 }
+
+func TestWithTimeoutPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "bad wolf" {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	checker := qt.WithTimeout(time.Minute, panicChecker{})
+	checker.Check(42, nil)
+	t.Fatalf("panic not propagated")
+}
+
+func TestViaNilChecker(t *testing.T) {
+	checker := qt.Via(strings.ToUpper, nil)
+	for _, err := range []error{
+		checker.Check("bad wolf", []interface{}{"BAD WOLF"}),
+		checker.Negate("bad wolf", []interface{}{"BAD WOLF"}),
+	} {
+		if !qt.IsBadCheck(err) || err.Error() != "nil checker provided" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+// panicChecker is a checker always panicking.
+type panicChecker struct{}
+
+func (panicChecker) Check(got interface{}, args []interface{}) error {
+	panic("bad wolf")
+}
+
+func (panicChecker) Negate(got interface{}, args []interface{}) error {
+	panic("bad wolf")
+}
+
+func (panicChecker) NumArgs() int {
+	return 0
+}
+
+// badCheckCheckers holds checkers whose invocations are misused, and
+// therefore always result in bad check errors.
+var badCheckCheckers = []struct {
+	about   string
+	checker qt.Checker
+	got     interface{}
+	args    []interface{}
+}{{
+	about:   "ErrorMatches",
+	checker: qt.ErrorMatches,
+	got:     42,
+	args:    []interface{}{".*"},
+}, {
+	about:   "HasLen",
+	checker: qt.HasLen,
+	got:     42,
+	args:    []interface{}{1},
+}, {
+	about:   "Matches",
+	checker: qt.Matches,
+	got:     "bad wolf",
+	args:    []interface{}{"("},
+}, {
+	about:   "Via",
+	checker: qt.Via(strings.ToUpper, qt.Equals),
+	got:     42,
+	args:    []interface{}{"BAD WOLF"},
+}}
+
+func TestContainsMatchStoresElement(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	crew := []companion{{Name: "Rose", Age: 19}, {Name: "Clara", Age: 27}, {Name: "Clara", Age: 42}}
+	var found companion
+	ok := c.Check(crew, qt.ContainsMatch(qt.FieldEquals("Name", "Clara")), &found)
+	checkResult(t, ok, tt.errorString(), "")
+	if found.Name != "Clara" || found.Age != 27 {
+		t.Fatalf("unexpected element stored: %#v", found)
+	}
+
+	// The pointed value is not modified when no elements match.
+	ok = c.Check(crew, qt.ContainsMatch(qt.FieldEquals("Name", "Amy")), &found)
+	assertBool(t, ok, false)
+	if found.Age != 27 {
+		t.Fatalf("unexpected element stored: %#v", found)
+	}
+}
+
+func TestNotPreservesBadCheck(t *testing.T) {
+	for _, test := range badCheckCheckers {
+		t.Run(test.about, func(t *testing.T) {
+			want := test.checker.Check(test.got, test.args)
+			if !qt.IsBadCheck(want) {
+				t.Fatalf("expected a bad check error, got %v", want)
+			}
+			checkers := []qt.Checker{
+				qt.Not(test.checker),
+				qt.Not(qt.Not(test.checker)),
+			}
+			for _, checker := range checkers {
+				for _, err := range []error{
+					checker.Check(test.got, test.args),
+					checker.Negate(test.got, test.args),
+				} {
+					if !qt.IsBadCheck(err) {
+						t.Fatalf("expected a bad check error, got %v", err)
+					}
+					if err.Error() != want.Error() {
+						t.Fatalf("unexpected error:\ngot  %q\nwant %q", err, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+var readerYieldsTests = []struct {
+	about                 string
+	reader                func() io.Reader
+	want                  []byte
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about:  "same data",
+	reader: func() io.Reader { return strings.NewReader("bad wolf") },
+	want:   []byte("bad wolf"),
+	expectedNegateFailure: "reader produced the expected data, but should not:\n(value)\n\t00000000  62 61 64 20 77 6f 6c 66",
+}, {
+	about:  "empty reader",
+	reader: func() io.Reader { return strings.NewReader("") },
+	want:   nil,
+	expectedNegateFailure: "reader produced the expected data, but should not:\n(value)\n\n",
+}, {
+	about:                "different data",
+	reader:               func() io.Reader { return strings.NewReader("bad wolf") },
+	want:                 []byte("bad fox"),
+	expectedCheckFailure: "reader produced unexpected data: first difference at offset 4 (0x4):\n(-got +want)\n",
+}, {
+	about:                "read error",
+	reader:               func() io.Reader { return io.MultiReader(strings.NewReader("bad"), errorReader{}) },
+	want:                 []byte("bad wolf"),
+	expectedCheckFailure: "cannot read from reader after 3 bytes:\n(error)\n\tbad wolf\n",
+}, {
+	about:                "endless reader",
+	reader:               func() io.Reader { return zeroReader{} },
+	want:                 []byte{0, 0},
+	expectedCheckFailure: "reader produced unexpected data: first difference at offset 2 (0x2):\n",
+}, {
+	about:                 "not a reader",
+	reader:                func() io.Reader { return nil },
+	want:                  []byte("bad wolf"),
+	expectedCheckFailure:  "expected an io.Reader, got <nil> instead\n",
+	expectedNegateFailure: "expected an io.Reader, got <nil> instead\n",
+}}
+
+func TestReaderYields(t *testing.T) {
+	for _, test := range readerYieldsTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.reader(), qt.ReaderYields(test.want))
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.reader(), qt.Not(qt.ReaderYields(test.want)))
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+func TestReaderYieldsTruncated(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.SetMaxReportLines(0)
+	ok := c.Check(zeroReader{}, qt.ReaderYields([]byte{0, 0}))
+	assertBool(t, ok, false)
+	if !strings.Contains(tt.errorString(), "\n(truncated)\n\treading stopped after 1026 bytes, more data is available\n") {
+		t.Fatalf("truncation not reported:\n%s", tt.errorString())
+	}
+}
+
+var readerFailsWithTests = []struct {
+	about                 string
+	reader                func() io.Reader
+	pattern               string
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about:   "matching error",
+	reader:  func() io.Reader { return io.MultiReader(strings.NewReader("bad"), errorReader{}) },
+	pattern: "bad .*",
+	expectedNegateFailure: "reader error matches the pattern, but should not:\n(error)\n\t\"bad wolf\"\n(pattern)\n\t\"bad .*\"\n(bytes read)\n\t3\n",
+}, {
+	about:                "error mismatch",
+	reader:               func() io.Reader { return io.MultiReader(strings.NewReader("bad"), errorReader{}) },
+	pattern:              "unexpected EOF",
+	expectedCheckFailure: "reader error mismatch:\n(-text +pattern)\n\t-: \"bad wolf\"\n\t+: \"unexpected EOF\"\n(bytes read)\n\t3\n",
+}, {
+	about:                "EOF",
+	reader:               func() io.Reader { return strings.NewReader("bad wolf") },
+	pattern:              "EOF",
+	expectedCheckFailure: "reader reached EOF without failing:\n(bytes read)\n\t8\n(pattern)\n\t\"EOF\"\n",
+}, {
+	about:                 "invalid pattern",
+	reader:                func() io.Reader { return strings.NewReader("bad wolf") },
+	pattern:               "(",
+	expectedCheckFailure:  "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`\n",
+	expectedNegateFailure: "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`\n",
+}, {
+	about:                 "not a reader",
+	reader:                func() io.Reader { return nil },
+	pattern:               ".*",
+	expectedCheckFailure:  "expected an io.Reader, got <nil> instead\n",
+	expectedNegateFailure: "expected an io.Reader, got <nil> instead\n",
+}}
+
+func TestReaderFailsWith(t *testing.T) {
+	for _, test := range readerFailsWithTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.reader(), qt.ReaderFailsWith(test.pattern))
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.reader(), qt.Not(qt.ReaderFailsWith(test.pattern)))
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+var readersEqualTests = []struct {
+	about                 string
+	got                   func() interface{}
+	want                  func() interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about: "same data",
+	got:   func() interface{} { return strings.NewReader("bad wolf") },
+	want:  func() interface{} { return strings.NewReader("bad wolf") },
+	expectedNegateFailure: "readers produce the same data, but should not:\n(length)\n\t8 bytes\n",
+}, {
+	about: "same large data",
+	got:   func() interface{} { return bytes.NewReader(bytes.Repeat([]byte("bad wolf"), 10000)) },
+	want:  func() interface{} { return io.MultiReader(strings.NewReader("bad"), bytes.NewReader(bytes.Repeat([]byte(" wolfbad"), 9999)), strings.NewReader(" wolf")) },
+	expectedNegateFailure: "readers produce the same data, but should not:\n(length)\n\t80000 bytes\n",
+}, {
+	about: "empty readers",
+	got:   func() interface{} { return strings.NewReader("") },
+	want:  func() interface{} { return strings.NewReader("") },
+	expectedNegateFailure: "readers produce the same data, but should not:\n(length)\n\t0 bytes\n",
+}, {
+	about:                "different data",
+	got:                  func() interface{} { return strings.NewReader("bad wolf") },
+	want:                 func() interface{} { return strings.NewReader("bad fox!") },
+	expectedCheckFailure: "readers differ at byte offset 4 (0x4):\n(-got +want)\n\t-: 00000000  62 61 64 20 77 6f 6c 66                           |bad wolf|\n\t+: 00000000  62 61 64 20 66 6f 78 21                           |bad fox!|\n\t                         ^^\n",
+}, {
+	about: "different large data",
+	got: func() interface{} {
+		data := make([]byte, 100000)
+		data[70000] = 1
+		return bytes.NewReader(data)
+	},
+	want:                 func() interface{} { return bytes.NewReader(make([]byte, 100000)) },
+	expectedCheckFailure: "readers differ at byte offset 70000 (0x11170):\n(-got +want)\n\t-: 00011170  01 00 00 00",
+}, {
+	about:                "reader ended early",
+	got:                  func() interface{} { return strings.NewReader("bad") },
+	want:                 func() interface{} { return strings.NewReader("bad wolf") },
+	expectedCheckFailure: "reader ended early after 3 bytes, while the expected reader has more data:\n(next expected bytes)\n\t00000000  62 61 64 20 77 6f 6c 66                           |bad wolf|\n",
+}, {
+	about:                "expected reader ended early",
+	got:                  func() interface{} { return strings.NewReader("bad wolf") },
+	want:                 func() interface{} { return strings.NewReader("") },
+	expectedCheckFailure: "expected reader ended early after 0 bytes, while the reader has more data:\n(next bytes)\n\t00000000  62 61 64 20 77 6f 6c 66                           |bad wolf|\n",
+}, {
+	about:                "read error",
+	got:                  func() interface{} { return io.MultiReader(strings.NewReader("bad"), errorReader{}) },
+	want:                 func() interface{} { return strings.NewReader("bad wolf") },
+	expectedCheckFailure: "cannot read from reader after 3 bytes:\n(error)\n\tbad wolf\n",
+}, {
+	about:                 "not a reader",
+	got:                   func() interface{} { return "bad wolf" },
+	want:                  func() interface{} { return strings.NewReader("bad wolf") },
+	expectedCheckFailure:  "expected an io.Reader, got string instead\n",
+	expectedNegateFailure: "expected an io.Reader, got string instead\n",
+}, {
+	about:                 "expected value not a reader",
+	got:                   func() interface{} { return strings.NewReader("bad wolf") },
+	want:                  func() interface{} { return "bad wolf" },
+	expectedCheckFailure:  "expected value is of type string, not io.Reader\n",
+	expectedNegateFailure: "expected value is of type string, not io.Reader\n",
+}}
+
+func TestReadersEqual(t *testing.T) {
+	for _, test := range readersEqualTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.ReadersEqual, test.want())
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.Not(qt.ReadersEqual), test.want())
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+// errorReader is an io.Reader always failing.
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("bad wolf")
+}
+
+// zeroReader is an io.Reader producing an endless stream of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestIsClosedPendingSender(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		ch <- 42
+	}()
+	tt := &testingT{}
+	c := qt.New(tt)
+	// Wait for the sender to be ready.
+	for {
+		if ok := c.Check(ch, qt.IsClosed); !ok && !strings.HasPrefix(tt.errorString(), "\nchannel is not closed:\n(state)\n\topen and empty\n") {
+			break
+		}
+		tt = &testingT{}
+		c = qt.New(tt)
+		time.Sleep(time.Millisecond)
+	}
+	assertPrefix(t, tt.errorString(), "\nchannel is not closed:\n(state)\n\topen with a pending value\n(received value)\n\t42\n")
+}
+
+// closedChan returns a closed channel.
+func closedChan() chan int {
+	ch := make(chan int)
+	close(ch)
+	return ch
+}
+
+// bufferedChan returns a buffered channel holding the given values.
+func bufferedChan(values ...int) chan int {
+	ch := make(chan int, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	return ch
+}
+
+func TestDiff(t *testing.T) {
+	if diff := qt.Diff([]int{1, 2}, []int{1, 2}); diff != "" {
+		t.Fatalf("unexpected diff for equal values:\n%s", diff)
+	}
+	if diff := qt.Diff([]int{2, 1}, []int{1, 2}, sameInts); diff != "" {
+		t.Fatalf("unexpected diff for values equal with options:\n%s", diff)
+	}
+	diff := qt.Diff([]int{1, 2}, []int{1, 3})
+	if diff == "" || strings.HasSuffix(diff, "\n") {
+		t.Fatalf("unexpected diff for different values: %q", diff)
+	}
+	// The diff is the same included in the CmpEquals failure report.
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check([]int{1, 2}, qt.DeepEquals, []int{1, 3})
+	checkResult(t, ok, tt.errorString(), "values are not equal (1 difference found):\n(-got +want)\n"+diff+"\n")
+}
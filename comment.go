@@ -2,7 +2,11 @@
 
 package quicktest
 
-import "fmt"
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
 
 // Commentf returns a test comment whose output is formatted according to
 // the given format specifier and args. It may be provided as the last argument
@@ -30,3 +34,40 @@ type Comment struct {
 func (c Comment) String() string {
 	return fmt.Sprintf(c.format, c.args...)
 }
+
+// WithSource returns the given value tagged with the file and line of the
+// WithSource call. When the returned value is provided as the value to check
+// to Check or Assert, the check is performed on v, and the location is
+// included in the failure report, like in "got value defined at
+// data_test.go:42". This is useful when values are defined far from the
+// assertion, for instance in table driven tests. For instance:
+//
+//     tests := []struct{
+//         about string
+//         got   interface{}
+//     }{{
+//         about: "answer",
+//         got:   qt.WithSource(answer()),
+//     }}
+//     for _, test := range tests {
+//         c.Check(test.got, qt.Equals, 42)
+//     }
+//
+// The location is only reported when the tagged value is the one being
+// checked, not when it is passed as an argument to the checker.
+func WithSource(v interface{}) interface{} {
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		return v
+	}
+	return sourcedValue{
+		value:  v,
+		source: fmt.Sprintf("%s:%d", filepath.Base(file), line),
+	}
+}
+
+// sourcedValue is a value tagged with the location where it was defined.
+type sourcedValue struct {
+	value  interface{}
+	source string
+}
@@ -0,0 +1,179 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// unifiedDiff returns a unified diff of the lines of the given texts, using
+// the given names as file names in the diff header. An empty string is
+// returned if the texts are equal.
+func unifiedDiff(fromName, from, toName, to string) string {
+	if from == to {
+		return ""
+	}
+	a, b := splitLines(from), splitLines(to)
+	edits := lineEdits(a, b)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(edits); {
+		// Find the next change, and the end of the hunk including it.
+		for start < len(edits) && edits[start].kind == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+		first := start - diffContextLines
+		if first < 0 {
+			first = 0
+		}
+		end, equal := start, 0
+		for end < len(edits) && equal <= 2*diffContextLines {
+			if edits[end].kind == ' ' {
+				equal++
+			} else {
+				equal = 0
+			}
+			end++
+		}
+		if equal > diffContextLines {
+			end -= equal - diffContextLines
+		}
+		hunk := edits[first:end]
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(hunk[0].a, count(hunk, '-')), hunkRange(hunk[0].b, count(hunk, '+')))
+		for _, e := range hunk {
+			if strings.HasSuffix(e.line, "\n") {
+				fmt.Fprintf(&buf, "%c%s", e.kind, e.line)
+				continue
+			}
+			// Report the last line of a text without a trailing newline the
+			// same way diff(1) does, so that texts differing only in their
+			// trailing newline produce a meaningful hunk.
+			fmt.Fprintf(&buf, "%c%s\n\\ No newline at end of file\n", e.kind, e.line)
+		}
+		start = end
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// diffContextLines holds the number of unchanged lines shown around changes
+// in unified diffs.
+const diffContextLines = 3
+
+// edit is a single line edit in a diff. The kind is ' ' for unchanged lines,
+// '-' for removed lines and '+' for added lines. The a and b fields hold the
+// zero based index of the line in the original and new texts.
+type edit struct {
+	kind byte
+	line string
+	a, b int
+}
+
+// lineEdits returns the edits transforming a into b, computed using Myers'
+// O(ND) difference algorithm. Only the furthest reaching paths found for each
+// number of differences are stored, so memory is proportional to the square
+// of the number of differences rather than to the size of the texts.
+func lineEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	// v[k+offset] holds the furthest x reached on diagonal k = x - y. The
+	// trace holds, for each number of differences d, the values of v for
+	// diagonals -d-1 to d+1 before the paths with d differences are explored.
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+search:
+	for d := 0; d < offset; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+	// Walk the trace backwards to collect the edits in reverse order.
+	edits := make([]edit, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[d+k] < v[d+k+2]) {
+			prevK = k + 1
+		}
+		prevX := v[d+1+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: ' ', line: a[x], a: x, b: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			edits = append(edits, edit{kind: '+', line: b[prevY], a: prevX, b: prevY})
+		} else {
+			edits = append(edits, edit{kind: '-', line: a[prevX], a: prevX, b: prevY})
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// count returns the number of lines in the original (kind '-') or new (kind
+// '+') text covered by the given edits.
+func count(edits []edit, kind byte) int {
+	n := 0
+	for _, e := range edits {
+		if e.kind == ' ' || e.kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// hunkRange formats the range of a unified diff hunk starting at the given
+// zero based line and including n lines.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+// splitLines splits the given text into lines, each one including its
+// terminating newline. The last line does not include a newline if the text
+// does not end with one, so that it is not equal to the same line followed by
+// a newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
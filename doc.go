@@ -25,5 +25,42 @@ instance:
 The library provides some base checkers like Equals, DeepEquals, Matches,
 ErrorMatches, IsNil and others. More can be added by implementing the Checker
 interface.
+
+Custom checkers
+
+Checkers defined in other packages are used exactly like the ones provided by
+quicktest, including when negated with Not. A checker implements the Checker
+interface:
+
+    type Checker interface {
+        Check(got interface{}, args []interface{}) error
+        Negate(got interface{}, args []interface{}) error
+        NumArgs() int
+    }
+
+NumArgs returns the number of arguments the checker expects after the value
+being checked. The number of arguments is validated before Check or Negate are
+called, so that args always has the expected length.
+
+Check returns nil when the check succeeds, and an error otherwise. Negate does
+the opposite, failing when Check would succeed. The message of the returned
+error is included in the failure report, so it should describe the problem and
+include the relevant values, for instance in the "(-got +want)" format used by
+the base checkers. Two special kinds of errors can also be returned:
+
+  - errors created with BadCheckf report that the checker has been misused,
+    for instance with arguments of the wrong type. Negate should return these
+    errors unchanged, so that they are never negated. Use IsBadCheck to detect
+    them, for instance when wrapping other checkers;
+  - errors created with SilentFailuref report a failure whose message is not
+    included in the report. Use IsSilentFailure to detect them.
+
+When Negate simply succeeds where Check fails, NegateCheckf can be used to
+implement it, so that negated failures are consistently phrased like the ones
+of the base checkers, for instance "the value is even, but should not":
+
+    func (c *evenChecker) Negate(got interface{}, args []interface{}) error {
+        return qt.NegateCheckf(c.Check(got, args), "the value is even:\n(value)\n\t%#v", got)
+    }
 */
 package quicktest
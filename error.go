@@ -2,7 +2,13 @@
 
 package quicktest
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
 
 // BadCheckf returns an error used to report a problem with the checker
 // invocation or testing execution itself (like wrong number or type of
@@ -27,6 +33,64 @@ func (e *badCheck) Error() string {
 	return string(*e)
 }
 
+// SilentFailuref returns an error used to report a check failure whose
+// message is not included in the failure output, for instance because the
+// checker already reported details about the failure in some other way.
+// The failure output still includes the comment, if any, and the invocation
+// context. This helper can be used when implementing checkers.
+func SilentFailuref(format string, a ...interface{}) error {
+	e := silentFailure(fmt.Sprintf(format, a...))
+	return &e
+}
+
+// IsSilentFailure reports whether the given error has been created by
+// SilentFailuref.
+// This helper can be used when implementing checkers.
+func IsSilentFailure(err error) bool {
+	_, ok := err.(*silentFailure)
+	return ok
+}
+
+type silentFailure string
+
+// Error implements the error interface.
+func (e *silentFailure) Error() string {
+	return string(*e)
+}
+
+// NegateCheckf returns the error to be returned by Checker.Negate, given the
+// error returned by Checker.Check for the same arguments. Bad check errors are
+// returned unchanged, and a Check failure is a Negate success. Otherwise, an
+// error is returned with the formatted message describing what was checked,
+// phrased as a positive statement: the standard ", but should not" suffix is
+// added to its first line, before a trailing colon introducing the reported
+// values, if any. For instance:
+//
+//     func (c *evenChecker) Negate(got interface{}, args []interface{}) error {
+//         return qt.NegateCheckf(c.Check(got, args), "the value is even:\n(value)\n\t%#v", got)
+//     }
+//
+// reports "the value is even, but should not:" followed by the value.
+// This helper can be used when implementing checkers.
+func NegateCheckf(checkErr error, format string, a ...interface{}) error {
+	if IsBadCheck(checkErr) {
+		return checkErr
+	}
+	if checkErr != nil {
+		return nil
+	}
+	msg := fmt.Sprintf(format, a...)
+	header, rest := msg, ""
+	if i := strings.Index(msg, "\n"); i >= 0 {
+		header, rest = msg[:i], msg[i:]
+	}
+	colon := ""
+	if strings.HasSuffix(header, ":") {
+		header, colon = strings.TrimSuffix(header, ":"), ":"
+	}
+	return fmt.Errorf("%s, but should not%s%s", header, colon, rest)
+}
+
 // mismatchError is an error that simplifies printing mismatch messages.
 type mismatchError struct {
 	msg     string
@@ -36,7 +100,7 @@ type mismatchError struct {
 
 // Error implements the error interface.
 func (e *mismatchError) Error() string {
-	return fmt.Sprintf("%s:\n(-text +pattern)\n\t-: %q\n\t+: %q", e.msg, e.got, e.pattern)
+	return fmt.Sprintf("%s:\n(-text +pattern)\n\t-: %s\n\t+: %s", e.msg, formatValue(e.got, "%q"), formatValue(e.pattern, "%q"))
 }
 
 // notEqualError is an error that simplifies printing "(-got +want)" messages.
@@ -48,7 +112,192 @@ type notEqualError struct {
 
 // Error implements the error interface.
 func (e *notEqualError) Error() string {
-	return fmt.Sprintf("%s:\n%s\t-: %#v\n\t+: %#v", e.msg, notEqualErrorPrefix, e.got, e.want)
+	return e.errorWith(valueFormatter{})
+}
+
+// errorWith implements formattedError.
+func (e *notEqualError) errorWith(f valueFormatter) string {
+	got, want := e.values(f)
+	if f.showTypes {
+		got, want = fmt.Sprintf("%s (%T)", got, e.got), fmt.Sprintf("%s (%T)", want, e.want)
+	}
+	return fmt.Sprintf("%s:\n%s\t-: %s\n\t+: %s", e.msg, notEqualErrorPrefix, got, want)
+}
+
+// values returns the got and want values formatted by f. By default, pointers
+// are dereferenced, and their addresses are also included if the pointed
+// values are formatted the same way, so that it is clear why they are not
+// equal.
+func (e *notEqualError) values(f valueFormatter) (got, want string) {
+	got, want = f.value(e.got, "%#v"), f.value(e.want, "%#v")
+	if got != want {
+		return got, want
+	}
+	if g, w := reflect.ValueOf(e.got), reflect.ValueOf(e.want); g.Kind() == reflect.Ptr && w.Kind() == reflect.Ptr {
+		return fmt.Sprintf("%s at %p", got, e.got), fmt.Sprintf("%s at %p", want, e.want)
+	}
+	return got, want
+}
+
+// formatValue formats the given value according to the given verb. Non-nil
+// pointers are dereferenced, so that the pointed value is displayed rather
+// than its address, and nil pointers are displayed as "nil" followed by their
+// type. Multi-line strings are formatted as a block of text delimited by
+// triple quotes, with their lines indented, so that they are easier to read
+// than quoted strings with escaped newlines. For instance:
+//
+//     """
+//         these are
+//         the voyages"""
+//
+// Strings including carriage returns or lines ending with white space are
+// still formatted as quoted strings, as those characters would not be visible
+// in a block of text.
+func formatValue(v interface{}, verb string) string {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Sprintf("nil (%T)", v)
+		}
+		if elem := rv.Elem(); elem.Kind() != reflect.Ptr {
+			// Only dereference one level, so that cyclic pointers are
+			// handled.
+			return "&" + formatValue(elem.Interface(), verb)
+		}
+	}
+	s, ok := v.(string)
+	if !ok || !isTextBlock(s) {
+		return fmt.Sprintf(verb, v)
+	}
+	return `"""` + "\n\t\t" + strings.Replace(s, "\n", "\n\t\t", -1) + `"""`
+}
+
+// isTextBlock reports whether s can be formatted as a block of text without
+// hiding any of its characters, that is whether it is a multi-line string
+// without carriage returns or trailing white space on its lines.
+func isTextBlock(s string) bool {
+	if !strings.Contains(s, "\n") || strings.Contains(s, "\r") {
+		return false
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimRightFunc(line, unicode.IsSpace) != line {
+			return false
+		}
+	}
+	return true
+}
+
+// formattedError is implemented by errors whose messages include reported
+// values, so that the way those values are displayed can be configured.
+type formattedError interface {
+	// errorWith returns the error message with the reported values
+	// formatted by f.
+	errorWith(f valueFormatter) string
+}
+
+// valueFormatter formats the values included in failure reports.
+type valueFormatter struct {
+	// format, if not nil, is used to format values instead of formatValue.
+	format func(v interface{}) string
+
+	// showTypes holds whether each value is followed by its type.
+	showTypes bool
+}
+
+// value formats v using the stored format function, if any, or using
+// formatValue with the given verb otherwise.
+func (f valueFormatter) value(v interface{}, verb string) string {
+	if f.format != nil {
+		return f.format(v)
+	}
+	return formatValue(v, verb)
 }
 
 const notEqualErrorPrefix = "(-got +want)\n"
+
+// bytesNotEqualError is an error reporting the differences between two byte
+// slices as hex dumps.
+type bytesNotEqualError struct {
+	msg  string
+	got  []byte
+	want []byte
+}
+
+// Error implements the error interface.
+func (e *bytesNotEqualError) Error() string {
+	offset := 0
+	for offset < len(e.got) && offset < len(e.want) && e.got[offset] == e.want[offset] {
+		offset++
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s: first difference at offset %d (0x%x):\n%s", e.msg, offset, offset, notEqualErrorPrefix)
+	for row := 0; row < len(e.got) || row < len(e.want); row += hexDumpRowSize {
+		gotRow, wantRow := bytesRow(e.got, row), bytesRow(e.want, row)
+		if row+hexDumpRowSize <= offset {
+			fmt.Fprintf(&buf, "\t : %s\n", hexDumpLine(row, gotRow))
+			continue
+		}
+		fmt.Fprintf(&buf, "\t-: %s\n", hexDumpLine(row, gotRow))
+		fmt.Fprintf(&buf, "\t+: %s\n", hexDumpLine(row, wantRow))
+		if row <= offset {
+			fmt.Fprintf(&buf, "\t   %s^^\n", strings.Repeat(" ", hexDumpColumn(offset-row)))
+		}
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// hexDump returns a hex dump of the given data in the style of "hexdump -C",
+// with each line preceded by the given prefix.
+func hexDump(data []byte, prefix string) string {
+	lines := make([]string, 0, len(data)/hexDumpRowSize+1)
+	for row := 0; row < len(data); row += hexDumpRowSize {
+		lines = append(lines, prefix+hexDumpLine(row, bytesRow(data, row)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hexDumpLine formats a single hex dump line for the given bytes, starting
+// at the given offset.
+func hexDumpLine(offset int, row []byte) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%08x ", offset)
+	for i := 0; i < hexDumpRowSize; i++ {
+		if i%8 == 0 {
+			buf.WriteByte(' ')
+		}
+		if i < len(row) {
+			fmt.Fprintf(&buf, "%02x ", row[i])
+		} else {
+			buf.WriteString("   ")
+		}
+	}
+	buf.WriteString(" |")
+	for _, b := range row {
+		if b < 32 || b > 126 {
+			b = '.'
+		}
+		buf.WriteByte(b)
+	}
+	buf.WriteByte('|')
+	return buf.String()
+}
+
+// hexDumpColumn returns the column at which the byte with the given index in
+// a row is displayed by hexDumpLine.
+func hexDumpColumn(i int) int {
+	return 10 + 3*i + i/8
+}
+
+// bytesRow returns the hex dump row of data starting at the given offset.
+func bytesRow(data []byte, offset int) []byte {
+	if offset >= len(data) {
+		return nil
+	}
+	end := offset + hexDumpRowSize
+	if end > len(data) {
+		end = len(data)
+	}
+	return data[offset:end]
+}
+
+// hexDumpRowSize holds the number of bytes displayed in each hex dump line.
+const hexDumpRowSize = 16
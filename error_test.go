@@ -23,3 +23,74 @@ func TestIsBadCheck(t *testing.T) {
 	err = errors.New("bad wolf")
 	assertBool(t, qt.IsBadCheck(err), false)
 }
+
+func TestSilentFailuref(t *testing.T) {
+	err := qt.SilentFailuref("bad %s", "wolf")
+	expectedMessage := "bad wolf"
+	if err.Error() != expectedMessage {
+		t.Fatalf("error:\ngot  %q\nwant %q", err, expectedMessage)
+	}
+}
+
+func TestIsSilentFailure(t *testing.T) {
+	err := qt.SilentFailuref("bad wolf")
+	assertBool(t, qt.IsSilentFailure(err), true)
+	assertBool(t, qt.IsBadCheck(err), false)
+	err = qt.BadCheckf("bad wolf")
+	assertBool(t, qt.IsSilentFailure(err), false)
+	err = errors.New("bad wolf")
+	assertBool(t, qt.IsSilentFailure(err), false)
+}
+
+var negateCheckfTests = []struct {
+	about           string
+	checkErr        error
+	format          string
+	args            []interface{}
+	expectedFailure string
+}{{
+	about:           "check success",
+	format:          "both values equal %d",
+	args:            []interface{}{42},
+	expectedFailure: "both values equal 42, but should not",
+}, {
+	about:           "check success with reported values",
+	format:          "the value is even:\n(value)\n\t%#v",
+	args:            []interface{}{42},
+	expectedFailure: "the value is even, but should not:\n(value)\n\t42",
+}, {
+	about:           "check success with colon in the middle of the header",
+	format:          "value: %q",
+	args:            []interface{}{"bad wolf"},
+	expectedFailure: "value: \"bad wolf\", but should not",
+}, {
+	about:    "check failure",
+	checkErr: errors.New("bad wolf"),
+	format:   "the value is even",
+}, {
+	about:           "bad check",
+	checkErr:        qt.BadCheckf("bad wolf"),
+	format:          "the value is even",
+	expectedFailure: "bad wolf",
+}}
+
+func TestNegateCheckf(t *testing.T) {
+	for _, test := range negateCheckfTests {
+		t.Run(test.about, func(t *testing.T) {
+			err := qt.NegateCheckf(test.checkErr, test.format, test.args...)
+			if test.expectedFailure == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("error:\ngot  <nil>\nwant %q", test.expectedFailure)
+			}
+			if err.Error() != test.expectedFailure {
+				t.Fatalf("error:\ngot  %q\nwant %q", err, test.expectedFailure)
+			}
+			assertBool(t, qt.IsBadCheck(err), qt.IsBadCheck(test.checkErr))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"fmt"
+	"strings"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// IsEven is a custom Checker checking that the provided integer is even.
+var IsEven qt.Checker = isEvenChecker{}
+
+type isEvenChecker struct{}
+
+// Check implements qt.Checker.Check by checking that got is an even int.
+func (isEvenChecker) Check(got interface{}, args []interface{}) error {
+	n, ok := got.(int)
+	if !ok {
+		// Report a misuse of the checker.
+		return qt.BadCheckf("expected an int, got %T instead", got)
+	}
+	if n%2 != 0 {
+		return fmt.Errorf("%d is not even", n)
+	}
+	return nil
+}
+
+// Negate implements qt.Checker.Negate by checking that got is an odd int.
+func (c isEvenChecker) Negate(got interface{}, args []interface{}) error {
+	err := c.Check(got, args)
+	if qt.IsBadCheck(err) {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	return fmt.Errorf("%d is even, but should not", got)
+}
+
+// NumArgs implements qt.Checker.NumArgs: no arguments other than the value
+// to check are required.
+func (isEvenChecker) NumArgs() int {
+	return 0
+}
+
+func ExampleChecker() {
+	runExampleTest(func(c *qt.C) {
+		c.Assert(42, IsEven)
+		c.Assert(47, qt.Not(IsEven))
+		c.Check(47, IsEven)
+		c.Check("42", qt.Not(IsEven))
+	})
+	// Output:
+	// 47 is not even
+	// expected an int, got string instead
+}
+
+// runExampleTest runs f with a checker that prints the first line of the
+// failure messages.
+func runExampleTest(f func(c *qt.C)) {
+	tt := &testingT{}
+	f(qt.New(tt))
+	for _, out := range []string{tt.fatalString(), tt.errorString()} {
+		for _, failure := range strings.Split(out, "\n\n") {
+			if lines := strings.Split(strings.TrimPrefix(failure, "\n"), "\n"); lines[0] != "" {
+				fmt.Println(lines[0])
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// CommandSucceeds is a Checker checking that the provided *exec.Cmd runs
+// successfully, exiting with a zero status. The command is run by the
+// checker, and its standard output and error are captured, so that they can
+// be included in the failure report along with the exit code.
+// For instance:
+//
+//     c.Assert(exec.Command("go", "vet", "./..."), qt.CommandSucceeds)
+//
+// If the command already has Stdout or Stderr set, its output is still
+// written there as well. The command must not have been started already.
+var CommandSucceeds Checker = &commandSucceedsChecker{}
+
+type commandSucceedsChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is a command which
+// exits with a zero status.
+func (c *commandSucceedsChecker) Check(got interface{}, args []interface{}) error {
+	result, err := runCommand(got)
+	if err != nil {
+		return err
+	}
+	if result.code == 0 {
+		return nil
+	}
+	return fmt.Errorf("command failed:\n%s", result)
+}
+
+// Negate implements Checker.Negate by checking that got is a command which
+// exits with a non-zero status.
+func (c *commandSucceedsChecker) Negate(got interface{}, args []interface{}) error {
+	result, err := runCommand(got)
+	if err != nil {
+		return err
+	}
+	if result.code != 0 {
+		return nil
+	}
+	return fmt.Errorf("command succeeded, but should not:\n%s", result)
+}
+
+// commandResult holds the outcome of running a command.
+type commandResult struct {
+	args   []string
+	code   int
+	stdout string
+	stderr string
+}
+
+// String returns the command result formatted as report sections.
+func (r *commandResult) String() string {
+	return fmt.Sprintf(
+		"(command)\n\t%s\n(exit code)\n\t%d\n(stdout)\n\t%s\n(stderr)\n\t%s",
+		strings.Join(r.args, " "), r.code, formatValue(r.stdout, "%q"), formatValue(r.stderr, "%q"))
+}
+
+// runCommand runs the given command, capturing its output.
+func runCommand(got interface{}) (*commandResult, error) {
+	cmd, ok := got.(*exec.Cmd)
+	if !ok || cmd == nil {
+		return nil, BadCheckf("expected a non-nil *exec.Cmd, got %T instead", got)
+	}
+	if cmd.Process != nil {
+		return nil, BadCheckf("command %q already started", strings.Join(cmd.Args, " "))
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = teeWriter(cmd.Stdout, &stdout)
+	cmd.Stderr = teeWriter(cmd.Stderr, &stderr)
+	err := cmd.Run()
+	result := &commandResult{
+		args:   cmd.Args,
+		stdout: stdout.String(),
+		stderr: stderr.String(),
+	}
+	if err == nil {
+		return result, nil
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return nil, BadCheckf("cannot run command %q: %s", strings.Join(cmd.Args, " "), err)
+	}
+	// ExitError.ExitCode is only available from Go 1.12, so retrieve the
+	// exit status from the system dependent information instead.
+	if status, ok := exitErr.Sys().(interface{ ExitStatus() int }); ok {
+		result.code = status.ExitStatus()
+	} else {
+		result.code = -1
+	}
+	return result, nil
+}
+
+// teeWriter returns a writer writing to both w, if not nil, and buf.
+func teeWriter(w io.Writer, buf *bytes.Buffer) io.Writer {
+	if w == nil {
+		return buf
+	}
+	return io.MultiWriter(w, buf)
+}
@@ -0,0 +1,121 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// commandEnv holds the name of the environment variable set when the test
+// binary is used as the command run by CommandSucceeds.
+const commandEnv = "QUICKTEST_COMMAND_HELPER"
+
+// TestCommandHelper is not a real test: it is used as the command run in the
+// CommandSucceeds tests, printing its arguments to stdout and stderr and
+// exiting with the status code provided in the environment.
+func TestCommandHelper(t *testing.T) {
+	code := os.Getenv(commandEnv)
+	if code == "" {
+		return
+	}
+	fmt.Fprint(os.Stdout, "these are\nthe voyages\n")
+	fmt.Fprint(os.Stderr, "bad wolf")
+	n, _ := strconv.Atoi(code)
+	os.Exit(n)
+}
+
+// helperCommand returns a command running TestCommandHelper, which exits with
+// the given status code.
+func helperCommand(code int) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCommandHelper$")
+	cmd.Env = append(os.Environ(), commandEnv+"="+strconv.Itoa(code))
+	return cmd
+}
+
+var commandSucceedsTests = []struct {
+	about                 string
+	got                   func() interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about: "success",
+	got: func() interface{} {
+		return helperCommand(0)
+	},
+	expectedNegateFailure: "command succeeded, but should not:\n(command)\n\t" + os.Args[0] + " -test.run=^TestCommandHelper$\n(exit code)\n\t0\n(stdout)\n\t\"\"\"\n\t\tthese are\n\t\tthe voyages\n\t\t\"\"\"\n(stderr)\n\t\"bad wolf\"\n",
+}, {
+	about: "failure",
+	got: func() interface{} {
+		return helperCommand(3)
+	},
+	expectedCheckFailure: "command failed:\n(command)\n\t" + os.Args[0] + " -test.run=^TestCommandHelper$\n(exit code)\n\t3\n(stdout)\n\t\"\"\"\n\t\tthese are\n\t\tthe voyages\n\t\t\"\"\"\n(stderr)\n\t\"bad wolf\"\n",
+}, {
+	about: "command not found",
+	got: func() interface{} {
+		return exec.Command("/no/such/command")
+	},
+	expectedCheckFailure:  "cannot run command \"/no/such/command\": ",
+	expectedNegateFailure: "cannot run command \"/no/such/command\": ",
+}, {
+	about: "command already started",
+	got: func() interface{} {
+		cmd := helperCommand(0)
+		if err := cmd.Run(); err != nil {
+			panic(err)
+		}
+		return cmd
+	},
+	expectedCheckFailure:  "command \"" + os.Args[0] + " -test.run=^TestCommandHelper$\" already started\n",
+	expectedNegateFailure: "command \"" + os.Args[0] + " -test.run=^TestCommandHelper$\" already started\n",
+}, {
+	about: "not a command",
+	got: func() interface{} {
+		return "go vet"
+	},
+	expectedCheckFailure:  "expected a non-nil *exec.Cmd, got string instead\n",
+	expectedNegateFailure: "expected a non-nil *exec.Cmd, got string instead\n",
+}, {
+	about: "nil command",
+	got: func() interface{} {
+		return (*exec.Cmd)(nil)
+	},
+	expectedCheckFailure:  "expected a non-nil *exec.Cmd, got *exec.Cmd instead\n",
+	expectedNegateFailure: "expected a non-nil *exec.Cmd, got *exec.Cmd instead\n",
+}}
+
+func TestCommandSucceeds(t *testing.T) {
+	for _, test := range commandSucceedsTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.CommandSucceeds)
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.Not(qt.CommandSucceeds))
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+func TestCommandSucceedsPreservesOutput(t *testing.T) {
+	var stdout bytes.Buffer
+	cmd := helperCommand(0)
+	cmd.Stdout = &stdout
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check(cmd, qt.CommandSucceeds)
+	checkResult(t, ok, tt.errorString(), "")
+	if got := stdout.String(); got != "these are\nthe voyages\n" {
+		t.Fatalf("unexpected stdout: %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// updateGolden holds whether golden files are updated by MatchesGolden
+// rather than checked.
+var updateGolden = flag.Bool("quicktest.update", false, "update the golden files used by quicktest MatchesGolden checks")
+
+// MatchesGolden returns a Checker checking that the provided value, as a
+// string or a []byte, is equal to the contents of the golden file at the
+// given path. On failure, a unified diff between the golden file and the
+// provided value is reported.
+// For instance:
+//
+//     c.Assert(output, qt.MatchesGolden("testdata/output.golden"))
+//
+// When tests are run with the -quicktest.update flag, the golden file is
+// written with the provided value instead, creating it and its directory if
+// required, and the check succeeds. For instance:
+//
+//     go test -run TestOutput -quicktest.update
+//
+func MatchesGolden(path string) Checker {
+	return &matchesGoldenChecker{
+		path: path,
+	}
+}
+
+type matchesGoldenChecker struct {
+	numArgs
+	path string
+}
+
+// Check implements Checker.Check by checking that got has the same contents
+// as the golden file, or by updating the golden file when requested.
+func (c *matchesGoldenChecker) Check(got interface{}, args []interface{}) error {
+	data, ok := documentBytes(got)
+	if !ok {
+		return BadCheckf("expected a string or []byte, got %T instead", got)
+	}
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+			return fmt.Errorf("cannot update golden file: %s", err)
+		}
+		if err := ioutil.WriteFile(c.path, data, 0644); err != nil {
+			return fmt.Errorf("cannot update golden file: %s", err)
+		}
+		return nil
+	}
+	want, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("golden file %s does not exist: run the test with -quicktest.update to create it", c.path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read golden file: %s", err)
+	}
+	if diff := unifiedDiff(c.path, string(want), "got", string(data)); diff != "" {
+		return errors.New("value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n" + indent(diff, "\t"))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got does not have the
+// same contents as the golden file. The golden file is never updated.
+func (c *matchesGoldenChecker) Negate(got interface{}, args []interface{}) error {
+	data, ok := documentBytes(got)
+	if !ok {
+		return BadCheckf("expected a string or []byte, got %T instead", got)
+	}
+	want, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("cannot read golden file: %s", err)
+	}
+	if string(want) != string(data) {
+		return nil
+	}
+	return fmt.Errorf("value matches the golden file %s, but should not", c.path)
+}
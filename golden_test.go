@@ -0,0 +1,143 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const goldenText = "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+
+var matchesGoldenTests = []struct {
+	about                 string
+	golden                string
+	got                   interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about:  "same contents",
+	golden: goldenText,
+	got:    goldenText,
+	expectedNegateFailure: "value matches the golden file ",
+}, {
+	about:  "same contents as bytes",
+	golden: goldenText,
+	got:    []byte(goldenText),
+	expectedNegateFailure: "value matches the golden file ",
+}, {
+	about:                "changed line",
+	golden:               goldenText,
+	got:                  strings.Replace(goldenText, "five", "5", 1),
+	expectedCheckFailure: "value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n\t--- GOLDEN\n\t+++ got\n\t@@ -2,7 +2,7 @@\n\t two\n\t three\n\t four\n\t-five\n\t+5\n\t six\n\t seven\n\t eight\n",
+}, {
+	about:                "separate hunks",
+	golden:               goldenText,
+	got:                  strings.Replace(strings.Replace(goldenText, "one\n", "", 1), "ten", "eleven", 1),
+	expectedCheckFailure: "value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n\t--- GOLDEN\n\t+++ got\n\t@@ -1,4 +1,3 @@\n\t-one\n\t two\n\t three\n\t four\n\t@@ -7,4 +6,4 @@\n\t seven\n\t eight\n\t nine\n\t-ten\n\t+eleven\n",
+}, {
+	about:                "added lines",
+	golden:               "",
+	got:                  "bad\nwolf\n",
+	expectedCheckFailure: "value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n\t--- GOLDEN\n\t+++ got\n\t@@ -0,0 +1,2 @@\n\t+bad\n\t+wolf\n",
+}, {
+	about:                "missing trailing newline",
+	golden:               "bad\nwolf\n",
+	got:                  "bad\nwolf",
+	expectedCheckFailure: "value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n\t--- GOLDEN\n\t+++ got\n\t@@ -1,2 +1,2 @@\n\t bad\n\t-wolf\n\t+wolf\n\t\\ No newline at end of file\n",
+}, {
+	about:                "added trailing newline",
+	golden:               "wolf",
+	got:                  "wolf\n",
+	expectedCheckFailure: "value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n\t--- GOLDEN\n\t+++ got\n\t@@ -1 +1 @@\n\t-wolf\n\t\\ No newline at end of file\n\t+wolf\n",
+}, {
+	about:                "large texts",
+	golden:               strings.Repeat("bad wolf\n", 100000),
+	got:                  strings.Repeat("bad wolf\n", 50000) + "time lord\n" + strings.Repeat("bad wolf\n", 50000),
+	expectedCheckFailure: "value does not match the golden file (run the test with -quicktest.update to update it):\n(diff)\n\t--- GOLDEN\n\t+++ got\n\t@@ -49998,6 +49998,7 @@\n\t bad wolf\n\t bad wolf\n\t bad wolf\n\t+time lord\n\t bad wolf\n\t bad wolf\n\t bad wolf\n",}, {
+	about:                 "not a string",
+	golden:                goldenText,
+	got:                   42,
+	expectedCheckFailure:  "expected a string or []byte, got int instead\n",
+	expectedNegateFailure: "expected a string or []byte, got int instead\n",
+}}
+
+func TestMatchesGolden(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	for i, test := range matchesGoldenTests {
+		path := filepath.Join(dir, fmt.Sprintf("test%d.golden", i))
+		if err := ioutil.WriteFile(path, []byte(test.golden), 0644); err != nil {
+			t.Fatalf("cannot write golden file: %v", err)
+		}
+		expectedCheckFailure := strings.Replace(test.expectedCheckFailure, "GOLDEN", path, 1)
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got, qt.MatchesGolden(path))
+			checkResult(t, ok, tt.errorString(), expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got, qt.Not(qt.MatchesGolden(path)))
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+func TestMatchesGoldenMissingFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "missing.golden")
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check("bad wolf", qt.MatchesGolden(path))
+	checkResult(t, ok, tt.errorString(), "golden file "+path+" does not exist: run the test with -quicktest.update to create it\n")
+}
+
+func TestMatchesGoldenUpdate(t *testing.T) {
+	setUpdateGolden(t, true)
+	defer setUpdateGolden(t, false)
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "output.golden")
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check("bad wolf\n", qt.MatchesGolden(path))
+	checkResult(t, ok, tt.errorString(), "")
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cannot read golden file: %v", err)
+	}
+	if string(data) != "bad wolf\n" {
+		t.Fatalf("golden file: got %q, want %q", data, "bad wolf\n")
+	}
+}
+
+// tempDir creates and returns a temporary directory.
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "quicktest-")
+	if err != nil {
+		t.Fatalf("cannot create temporary directory: %v", err)
+	}
+	return dir
+}
+
+// setUpdateGolden sets the value of the flag used to update golden files.
+func setUpdateGolden(t *testing.T, update bool) {
+	value := "false"
+	if update {
+		value = "true"
+	}
+	if err := flag.Set("quicktest.update", value); err != nil {
+		t.Fatalf("cannot set update flag: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// attributionEnv holds the name of the environment variable set when running
+// the failing checks in TestHelperAttribution.
+const attributionEnv = "QUICKTEST_HELPER_ATTRIBUTION"
+
+// attributionRegexp matches the file and line attribution added by the
+// testing package to failure messages.
+var attributionRegexp = regexp.MustCompile(`(?m)^\s+(\S+\.go):\d+: `)
+
+// TestHelperAttribution checks that the testing package attributes failures
+// to the line of the Check or Assert invocation, rather than to quicktest
+// internals. It does so by running the test binary again in a subprocess,
+// where the checks are executed using a real *testing.T and fail.
+func TestHelperAttribution(t *testing.T) {
+	if os.Getenv(attributionEnv) != "" {
+		c := qt.New(t)
+		c.Check(42, qt.Equals, 47)
+		c.Checkf(42, qt.Equals, 47, "answer %d", 42)
+		c.WithComment("with comment").Check(42, qt.IsNil)
+		c.CheckAll(func(c *qt.C) {
+			c.Assert(42, qt.Equals, 47)
+		})
+		c.Run("subtest", func(c *qt.C) {
+			c.Assert(42, qt.Equals, 47)
+		})
+		c.Assert(42, qt.Equals, 47)
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperAttribution$")
+	cmd.Env = append(os.Environ(), attributionEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("subprocess unexpectedly succeeded:\n%s", out)
+	}
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Fatalf("cannot run subprocess: %v", err)
+	}
+	matches := attributionRegexp.FindAllStringSubmatch(string(out), -1)
+	for _, m := range matches {
+		if m[1] != "helper_test.go" {
+			t.Fatalf("failure attributed to %s:\n%s", m[1], out)
+		}
+	}
+	if len(matches) != 6 {
+		t.Fatalf("got %d attributed failures, want 6:\n%s", len(matches), out)
+	}
+}
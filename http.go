@@ -0,0 +1,203 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// HasStatus returns a Checker checking that the provided HTTP response, as an
+// *http.Response or an *httptest.ResponseRecorder, has the given status
+// code. On failure, the beginning of the response body is also reported.
+// For instance:
+//
+//     c.Assert(resp, qt.HasStatus(http.StatusOK))
+//
+func HasStatus(code int) Checker {
+	return &hasStatusChecker{
+		code: code,
+	}
+}
+
+type hasStatusChecker struct {
+	numArgs
+	code int
+}
+
+// Check implements Checker.Check by checking that got is a response with the
+// stored status code.
+func (c *hasStatusChecker) Check(got interface{}, args []interface{}) error {
+	code, body, err := responseStatus(got)
+	if err != nil {
+		return err
+	}
+	if code == c.code {
+		return nil
+	}
+	return fmt.Errorf("unexpected status code:\n(-got +want)\n\t-: %s\n\t+: %s\n(body)\n\t%q", statusString(code), statusString(c.code), body)
+}
+
+// Negate implements Checker.Negate by checking that got is a response with a
+// status code different from the stored one.
+func (c *hasStatusChecker) Negate(got interface{}, args []interface{}) error {
+	return NegateCheckf(c.Check(got, args), "response has status code %s", statusString(c.code))
+}
+
+// HasHeader returns a Checker checking that the provided HTTP headers, as
+// http.Header, or the headers of the provided *http.Response or
+// *httptest.ResponseRecorder, include the given value for the given header.
+// The check succeeds if any of the header values, or any of the elements of
+// comma separated header values, is equal to the given value.
+// For instance:
+//
+//     c.Assert(resp, qt.HasHeader("Content-Type", "application/json"))
+//     c.Assert(resp, qt.HasHeader("Cache-Control", "no-store"))
+//
+// Header names are case insensitive. Use HasHeaderFold to also ignore case
+// when comparing values.
+func HasHeader(name, value string) Checker {
+	return &hasHeaderChecker{
+		name:  name,
+		value: value,
+	}
+}
+
+// HasHeaderFold is like HasHeader, but header values are compared ignoring
+// case. For instance:
+//
+//     c.Assert(resp, qt.HasHeaderFold("Connection", "keep-alive"))
+//
+func HasHeaderFold(name, value string) Checker {
+	return &hasHeaderChecker{
+		name:  name,
+		value: value,
+		fold:  true,
+	}
+}
+
+type hasHeaderChecker struct {
+	numArgs
+	name  string
+	value string
+	fold  bool
+}
+
+// Check implements Checker.Check by checking that got has a header with the
+// stored name including the stored value.
+func (c *hasHeaderChecker) Check(got interface{}, args []interface{}) error {
+	h, err := responseHeader(got)
+	if err != nil {
+		return err
+	}
+	values := h[http.CanonicalHeaderKey(c.name)]
+	if len(values) == 0 {
+		return fmt.Errorf("header %q not found:\n(want)\n\t%q", c.name, c.value)
+	}
+	for _, v := range values {
+		if c.matches(v) {
+			return nil
+		}
+		for _, elem := range strings.Split(v, ",") {
+			if c.matches(strings.TrimSpace(elem)) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("header %q does not include the expected value:\n(got values)\n\t%q\n(want)\n\t%q", c.name, values, c.value)
+}
+
+// Negate implements Checker.Negate by checking that got does not have a
+// header with the stored name including the stored value.
+func (c *hasHeaderChecker) Negate(got interface{}, args []interface{}) error {
+	h, _ := responseHeader(got)
+	return NegateCheckf(c.Check(got, args), "header %q includes %q:\n(got values)\n\t%q", c.name, c.value, h[http.CanonicalHeaderKey(c.name)])
+}
+
+// matches reports whether the given header value matches the stored value.
+func (c *hasHeaderChecker) matches(v string) bool {
+	if c.fold {
+		return strings.EqualFold(v, c.value)
+	}
+	return v == c.value
+}
+
+// responseHeader returns the headers of the given response.
+func responseHeader(resp interface{}) (http.Header, error) {
+	switch r := resp.(type) {
+	case http.Header:
+		return r, nil
+	case *http.Response:
+		if r == nil {
+			return nil, BadCheckf("expected an HTTP response, got a nil *http.Response")
+		}
+		return r.Header, nil
+	case *httptest.ResponseRecorder:
+		if r == nil {
+			return nil, BadCheckf("expected an HTTP response, got a nil *httptest.ResponseRecorder")
+		}
+		return r.Header(), nil
+	}
+	return nil, BadCheckf("expected an http.Header, *http.Response or *httptest.ResponseRecorder, got %T instead", resp)
+}
+
+// maxReportedBodyBytes holds the maximum number of bytes of a response body
+// included in failure reports.
+const maxReportedBodyBytes = 1024
+
+// responseStatus returns the status code and the beginning of the body of
+// the given response. The body of an *http.Response is preserved, so that it
+// can still be read after the check.
+func responseStatus(resp interface{}) (code int, body string, err error) {
+	var data []byte
+	switch r := resp.(type) {
+	case *http.Response:
+		if r == nil {
+			return 0, "", BadCheckf("expected an HTTP response, got a nil *http.Response")
+		}
+		code = r.StatusCode
+		if r.Body != nil {
+			data, err = ioutil.ReadAll(io.LimitReader(r.Body, maxReportedBodyBytes+1))
+			if err != nil {
+				return 0, "", fmt.Errorf("cannot read response body: %s", err)
+			}
+			r.Body = readCloser{
+				Reader: io.MultiReader(bytes.NewReader(data), r.Body),
+				Closer: r.Body,
+			}
+		}
+	case *httptest.ResponseRecorder:
+		if r == nil {
+			return 0, "", BadCheckf("expected an HTTP response, got a nil *httptest.ResponseRecorder")
+		}
+		code = r.Code
+		if r.Body != nil {
+			data = r.Body.Bytes()
+		}
+	default:
+		return 0, "", BadCheckf("expected an *http.Response or *httptest.ResponseRecorder, got %T instead", resp)
+	}
+	if len(data) > maxReportedBodyBytes {
+		return code, string(data[:maxReportedBodyBytes]) + "...", nil
+	}
+	return code, string(data), nil
+}
+
+// statusString returns the given status code followed by its description.
+func statusString(code int) string {
+	if text := http.StatusText(code); text != "" {
+		return fmt.Sprintf("%d (%s)", code, text)
+	}
+	return fmt.Sprint(code)
+}
+
+// readCloser combines a reader and a closer into an io.ReadCloser.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,184 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var hasStatusTests = []struct {
+	about                 string
+	got                   func() interface{}
+	code                  int
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about: "response with expected status",
+	got:   func() interface{} { return newResponse(http.StatusOK, "bad wolf") },
+	code:  http.StatusOK,
+	expectedNegateFailure: "response has status code 200 (OK), but should not\n",
+}, {
+	about: "recorder with expected status",
+	got:   func() interface{} { return newRecorder(http.StatusNotFound, "") },
+	code:  http.StatusNotFound,
+	expectedNegateFailure: "response has status code 404 (Not Found), but should not\n",
+}, {
+	about:                "response with unexpected status",
+	got:                  func() interface{} { return newResponse(http.StatusInternalServerError, "exterminate") },
+	code:                 http.StatusOK,
+	expectedCheckFailure: "unexpected status code:\n(-got +want)\n\t-: 500 (Internal Server Error)\n\t+: 200 (OK)\n(body)\n\t\"exterminate\"\n",
+}, {
+	about:                "recorder with unexpected status",
+	got:                  func() interface{} { return newRecorder(http.StatusBadRequest, "invalid request") },
+	code:                 299,
+	expectedCheckFailure: "unexpected status code:\n(-got +want)\n\t-: 400 (Bad Request)\n\t+: 299\n(body)\n\t\"invalid request\"\n",
+}, {
+	about:                "truncated body",
+	got:                  func() interface{} { return newResponse(http.StatusTeapot, strings.Repeat("x", 2000)) },
+	code:                 http.StatusOK,
+	expectedCheckFailure: "unexpected status code:\n(-got +want)\n\t-: 418 (I'm a teapot)\n\t+: 200 (OK)\n(body)\n\t\"" + strings.Repeat("x", 1024) + "...\"\n",
+}, {
+	about:                 "nil response",
+	got:                   func() interface{} { return (*http.Response)(nil) },
+	code:                  http.StatusOK,
+	expectedCheckFailure:  "expected an HTTP response, got a nil *http.Response\n",
+	expectedNegateFailure: "expected an HTTP response, got a nil *http.Response\n",
+}, {
+	about:                 "not a response",
+	got:                   func() interface{} { return 200 },
+	code:                  http.StatusOK,
+	expectedCheckFailure:  "expected an *http.Response or *httptest.ResponseRecorder, got int instead\n",
+	expectedNegateFailure: "expected an *http.Response or *httptest.ResponseRecorder, got int instead\n",
+}}
+
+func TestHasStatus(t *testing.T) {
+	for _, test := range hasStatusTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.HasStatus(test.code))
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.Not(qt.HasStatus(test.code)))
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+func TestHasStatusPreservesBody(t *testing.T) {
+	resp := newResponse(http.StatusNotFound, "bad wolf")
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.Check(resp, qt.HasStatus(http.StatusOK))
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("cannot read body: %v", err)
+	}
+	if string(data) != "bad wolf" {
+		t.Fatalf("body: got %q, want %q", data, "bad wolf")
+	}
+}
+
+var hasHeaderTests = []struct {
+	about                 string
+	checker               qt.Checker
+	got                   interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about:   "header value",
+	checker: qt.HasHeader("Content-Type", "application/json"),
+	got:     http.Header{"Content-Type": {"application/json"}},
+	expectedNegateFailure: "header \"Content-Type\" includes \"application/json\", but should not:\n(got values)\n\t[\"application/json\"]\n",
+}, {
+	about:   "case insensitive header name",
+	checker: qt.HasHeader("content-type", "text/plain"),
+	got:     &http.Response{Header: http.Header{"Content-Type": {"text/plain"}}},
+	expectedNegateFailure: "header \"content-type\" includes \"text/plain\", but should not:\n",
+}, {
+	about:   "one of multiple values",
+	checker: qt.HasHeader("Vary", "Accept"),
+	got:     http.Header{"Vary": {"Origin", "Accept"}},
+	expectedNegateFailure: "header \"Vary\" includes \"Accept\", but should not:\n(got values)\n\t[\"Origin\" \"Accept\"]\n",
+}, {
+	about:   "comma separated value",
+	checker: qt.HasHeader("Cache-Control", "no-store"),
+	got:     newRecorderWithHeader("Cache-Control", "no-cache, no-store"),
+	expectedNegateFailure: "header \"Cache-Control\" includes \"no-store\", but should not:\n",
+}, {
+	about:                "value mismatch",
+	checker:              qt.HasHeader("Content-Type", "application/json"),
+	got:                  http.Header{"Content-Type": {"text/html", "text/plain"}},
+	expectedCheckFailure: "header \"Content-Type\" does not include the expected value:\n(got values)\n\t[\"text/html\" \"text/plain\"]\n(want)\n\t\"application/json\"\n",
+}, {
+	about:                "case sensitive value",
+	checker:              qt.HasHeader("Connection", "keep-alive"),
+	got:                  http.Header{"Connection": {"Keep-Alive"}},
+	expectedCheckFailure: "header \"Connection\" does not include the expected value:\n(got values)\n\t[\"Keep-Alive\"]\n(want)\n\t\"keep-alive\"\n",
+}, {
+	about:   "case insensitive value",
+	checker: qt.HasHeaderFold("Connection", "keep-alive"),
+	got:     http.Header{"Connection": {"Keep-Alive"}},
+	expectedNegateFailure: "header \"Connection\" includes \"keep-alive\", but should not:\n(got values)\n\t[\"Keep-Alive\"]\n",
+}, {
+	about:                "header not found",
+	checker:              qt.HasHeader("Location", "/"),
+	got:                  http.Header{},
+	expectedCheckFailure: "header \"Location\" not found:\n(want)\n\t\"/\"\n",
+}, {
+	about:                 "not headers",
+	checker:               qt.HasHeader("Location", "/"),
+	got:                   "Location: /",
+	expectedCheckFailure:  "expected an http.Header, *http.Response or *httptest.ResponseRecorder, got string instead\n",
+	expectedNegateFailure: "expected an http.Header, *http.Response or *httptest.ResponseRecorder, got string instead\n",
+}}
+
+func TestHasHeader(t *testing.T) {
+	for _, test := range hasHeaderTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got, test.checker)
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got, qt.Not(test.checker))
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
+
+// newRecorderWithHeader returns a response recorder with the given header.
+func newRecorderWithHeader(name, value string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(name, value)
+	return rec
+}
+
+// newResponse returns an HTTP response with the given status code and body.
+func newResponse(code int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// newRecorder returns a response recorder with the given status code and
+// body.
+func newRecorder(code int, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(code)
+	rec.WriteString(body)
+	return rec
+}
@@ -0,0 +1,179 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LatencyPercentile returns a Checker checking that the provided func(),
+// when called n times, has the given latency percentile within max. The
+// percentile must be greater than 0 and not greater than 100, and it is
+// computed using the nearest-rank method over the measured durations.
+// For instance, to check that 95% of the calls complete within 10ms:
+//
+//     c.Assert(func() { cache.Get("key") }, qt.LatencyPercentile(100, 95, 10*time.Millisecond))
+//
+// The function is called sequentially in the current goroutine. The measured
+// percentile and a histogram of the measured durations are included in
+// failure reports.
+func LatencyPercentile(n int, percentile float64, max time.Duration) Checker {
+	return &latencyPercentileChecker{
+		n:          n,
+		percentile: percentile,
+		max:        max,
+	}
+}
+
+type latencyPercentileChecker struct {
+	numArgs
+	n          int
+	percentile float64
+	max        time.Duration
+}
+
+// Check implements Checker.Check by checking that the stored percentile of
+// the durations of calls to got is not greater than the stored maximum.
+func (c *latencyPercentileChecker) Check(got interface{}, args []interface{}) error {
+	result, err := c.measure(got)
+	if err != nil {
+		return err
+	}
+	if result.value <= c.max {
+		return nil
+	}
+	return fmt.Errorf("latency percentile exceeds the maximum:\n%s", result)
+}
+
+// Negate implements Checker.Negate by checking that the stored percentile of
+// the durations of calls to got is greater than the stored maximum.
+func (c *latencyPercentileChecker) Negate(got interface{}, args []interface{}) error {
+	result, err := c.measure(got)
+	if err != nil {
+		return err
+	}
+	if result.value > c.max {
+		return nil
+	}
+	return fmt.Errorf("latency percentile is within the maximum, but should not:\n%s", result)
+}
+
+// measure calls the given function the stored number of times, and returns
+// the resulting latency measurements.
+func (c *latencyPercentileChecker) measure(got interface{}) (*latencyResult, error) {
+	f, ok := got.(func())
+	if !ok {
+		return nil, BadCheckf("expected a func(), got %T instead", got)
+	}
+	if c.n <= 0 {
+		return nil, BadCheckf("number of runs must be positive, got %d", c.n)
+	}
+	if c.percentile <= 0 || c.percentile > 100 || math.IsNaN(c.percentile) {
+		return nil, BadCheckf("percentile must be greater than 0 and not greater than 100, got %v", c.percentile)
+	}
+	durations := make([]time.Duration, c.n)
+	for i := range durations {
+		start := time.Now()
+		f()
+		durations[i] = time.Since(start)
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	rank := int(math.Ceil(c.percentile / 100 * float64(c.n)))
+	if rank < 1 {
+		rank = 1
+	}
+	return &latencyResult{
+		percentile: c.percentile,
+		value:      durations[rank-1],
+		max:        c.max,
+		durations:  durations,
+	}, nil
+}
+
+// latencyResult holds the outcome of latency measurements.
+type latencyResult struct {
+	percentile float64
+	value      time.Duration
+	max        time.Duration
+	// durations holds the measured durations, in increasing order.
+	durations []time.Duration
+}
+
+// String returns the latency result formatted as report sections.
+func (r *latencyResult) String() string {
+	return fmt.Sprintf(
+		"(percentile)\n\tp%g\n(measured)\n\t%v\n(max)\n\t%v\n(runs)\n\t%d\n(histogram)\n%s",
+		r.percentile, roundDuration(r.value), r.max, len(r.durations), r.histogram())
+}
+
+// histogram returns a histogram of the measured durations, with one
+// indented line per bucket.
+func (r *latencyResult) histogram() string {
+	min, max := r.durations[0], r.durations[len(r.durations)-1]
+	buckets := latencyHistogramBuckets
+	if min == max {
+		buckets = 1
+	}
+	width := (max - min) / time.Duration(buckets)
+	counts := make([]int, buckets)
+	var maxCount int
+	for _, d := range r.durations {
+		i := buckets - 1
+		if width > 0 && int((d-min)/width) < buckets {
+			i = int((d - min) / width)
+		}
+		counts[i]++
+		if counts[i] > maxCount {
+			maxCount = counts[i]
+		}
+	}
+	labels := make([]string, buckets)
+	var labelWidth int
+	for i := range labels {
+		from, to := min+time.Duration(i)*width, min+time.Duration(i+1)*width
+		if i == buckets-1 {
+			to = max
+		}
+		labels[i] = roundDuration(from).String() + " - " + roundDuration(to).String()
+		if len(labels[i]) > labelWidth {
+			labelWidth = len(labels[i])
+		}
+	}
+	var buf bytes.Buffer
+	for i, count := range counts {
+		bar := strings.Repeat("#", (count*latencyHistogramWidth+maxCount-1)/maxCount)
+		fmt.Fprintf(&buf, "\t%-*s  %-*s  %d\n", labelWidth, labels[i], latencyHistogramWidth, bar, count)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// roundDuration rounds the given duration to the microsecond if it is longer
+// than a millisecond, so that it is easier to read.
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d
+	}
+	// Round by hand, as time.Duration.Round is only available from Go 1.9.
+	r := d % time.Microsecond
+	d -= r
+	if r+r >= time.Microsecond {
+		d += time.Microsecond
+	}
+	return d
+}
+
+const (
+	// latencyHistogramBuckets holds the number of buckets in the histograms
+	// included in LatencyPercentile failure reports.
+	latencyHistogramBuckets = 5
+
+	// latencyHistogramWidth holds the maximum width of histogram bars.
+	latencyHistogramWidth = 20
+)
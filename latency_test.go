@@ -0,0 +1,48 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestLatencyPercentileReport(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	var calls int
+	ok := c.Check(func() {
+		calls++
+		time.Sleep(time.Duration(calls) * time.Millisecond)
+	}, qt.LatencyPercentile(4, 75, time.Nanosecond))
+	assertBool(t, ok, false)
+	if calls != 4 {
+		t.Fatalf("unexpected number of calls: got %d, want 4", calls)
+	}
+	report := tt.errorString()
+	if !strings.Contains(report, "\n(max)\n\t1ns\n(runs)\n\t4\n(histogram)\n\t") {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+	lines := strings.Split(report[strings.Index(report, "(histogram)\n"):], "\n")
+	// The histogram has a header line followed by one line per bucket, and
+	// the bucket counts add up to the number of runs.
+	var total int
+	for _, line := range lines[1:6] {
+		fields := strings.Fields(line)
+		if !strings.HasPrefix(line, "\t") || len(fields) < 4 {
+			t.Fatalf("unexpected histogram line %q in report:\n%s", line, report)
+		}
+		count, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			t.Fatalf("unexpected histogram line %q in report:\n%s", line, report)
+		}
+		total += count
+	}
+	if total != 4 {
+		t.Fatalf("unexpected histogram total: got %d, want 4\n%s", total, report)
+	}
+}
@@ -0,0 +1,102 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// CheckNoGoroutineLeaks records the number of goroutines currently running,
+// and checks that the same number of goroutines is running when the test
+// completes, allowing a short time for goroutines to terminate. On failure,
+// the stack traces of the goroutines started since the call are reported,
+// excluding the ones running only runtime or testing code. For instance:
+//
+//     func TestServer(t *testing.T) {
+//         c := qt.New(t)
+//         c.CheckNoGoroutineLeaks()
+//         srv := startServer()
+//         defer srv.Close()
+//         ...
+//     }
+//
+// Note that goroutines started by tests running in parallel are also counted.
+// A panic is raised when CheckNoGoroutineLeaks is called and the embedded
+// concrete type does not implement Cleanup.
+func (c *C) CheckNoGoroutineLeaks() {
+	cl, ok := c.TB.(cleaner)
+	if !ok {
+		panic(fmt.Sprintf("cannot execute CheckNoGoroutineLeaks with underlying concrete type %T", c.TB))
+	}
+	baseline := runtime.NumGoroutine()
+	ids := make(map[string]bool)
+	for _, stack := range goroutineStacks() {
+		ids[goroutineID(stack)] = true
+	}
+	file, line, found := invocation()
+	cl.Cleanup(func() {
+		c.stats.add(checkKind)
+		n := runtime.NumGoroutine()
+		deadline := time.Now().Add(goroutineLeakTimeout)
+		for n > baseline && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+			n = runtime.NumGoroutine()
+		}
+		if n <= baseline {
+			return
+		}
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "goroutines leaked: got %d running, want %d\n(goroutines)", n, baseline)
+		for _, stack := range goroutineStacks() {
+			if !ids[goroutineID(stack)] && !isSystemGoroutine(stack) {
+				fmt.Fprintf(&buf, "\n%s", indent(stack, "\t"))
+			}
+		}
+		c.fail(c.TB.Error, c.reportAt(errors.New(buf.String()), Comment{}, file, line, found))
+	})
+}
+
+// goroutineLeakTimeout holds the maximum time to wait for goroutines to
+// terminate when checking for leaks.
+var goroutineLeakTimeout = time.Second
+
+// goroutineStacks returns the stack traces of all running goroutines.
+func goroutineStacks() []string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return strings.Split(strings.TrimSpace(string(buf[:n])), "\n\n")
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineID returns the identifier of the goroutine with the given stack
+// trace, whose first line is similar to "goroutine 42 [running]:".
+func goroutineID(stack string) string {
+	fields := strings.Fields(stack)
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+// isSystemGoroutine reports whether the goroutine with the given stack trace
+// is only running runtime or testing code.
+func isSystemGoroutine(stack string) bool {
+	for _, line := range strings.Split(stack, "\n")[1:] {
+		if strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "created by ") {
+			continue
+		}
+		if !strings.HasPrefix(line, "runtime.") && !strings.HasPrefix(line, "testing.") {
+			return false
+		}
+	}
+	return true
+}
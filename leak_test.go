@@ -0,0 +1,48 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCheckNoGoroutineLeaks(t *testing.T) {
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.CheckNoGoroutineLeaks()
+	done := make(chan struct{})
+	go func() {
+		close(done)
+	}()
+	<-done
+	tt.cleanup()
+	if tt.errorString() != "" {
+		t.Fatalf("unexpected failure:\n%s", tt.errorString())
+	}
+}
+
+func TestCheckNoGoroutineLeaksFailure(t *testing.T) {
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.CheckNoGoroutineLeaks()
+	stop := make(chan struct{})
+	defer close(stop)
+	go leakingGoroutine(stop)
+	tt.cleanup()
+	got := tt.errorString()
+	assertPrefix(t, got, "\ngoroutines leaked: got ")
+	if !strings.Contains(got, "\n(goroutines)\n\tgoroutine ") || !strings.Contains(got, "quicktest_test.leakingGoroutine(") {
+		t.Fatalf("leaked goroutine not reported:\n%s", got)
+	}
+	if !strings.Contains(got, "\nleak_test.go:30:\n") {
+		t.Fatalf("registration not reported:\n%s", got)
+	}
+}
+
+// leakingGoroutine blocks until stop is closed.
+func leakingGoroutine(stop chan struct{}) {
+	<-stop
+}
@@ -0,0 +1,144 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// CaptureLog redirects the output of the standard logger to the returned
+// buffer until the test completes, at which point the previous output and
+// flags are restored. Date and time flags are disabled while capturing, so
+// that the logged lines are predictable. The returned buffer can be checked
+// using LoggedMatches. For instance:
+//
+//     func TestRetry(t *testing.T) {
+//         c := qt.New(t)
+//         logs := c.CaptureLog()
+//         retry(failingOp)
+//         c.Assert(logs, qt.LoggedMatches, "retrying after .*")
+//     }
+//
+// Note that the standard logger is global, so tests capturing its output
+// should not be run in parallel. Also, the standard logger output cannot be
+// retrieved before Go 1.12: in that case, it is reset to os.Stderr when the
+// test completes. A panic is raised when CaptureLog is called
+// and the embedded concrete type does not implement Cleanup.
+func (c *C) CaptureLog() *LogBuffer {
+	cl, ok := c.TB.(cleaner)
+	if !ok {
+		panic(fmt.Sprintf("cannot execute CaptureLog with underlying concrete type %T", c.TB))
+	}
+	buf := &LogBuffer{}
+	output, flags := logWriter(), log.Flags()
+	log.SetOutput(buf)
+	log.SetFlags(flags &^ (log.Ldate | log.Ltime | log.Lmicroseconds))
+	cl.Cleanup(func() {
+		log.SetOutput(output)
+		log.SetFlags(flags)
+	})
+	return buf
+}
+
+// LogBuffer is an io.Writer collecting logged lines. It is safe to use it
+// concurrently.
+type LogBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write implements io.Writer.Write.
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// String returns the output collected so far.
+func (b *LogBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// Lines returns the lines collected so far, without their trailing newlines.
+func (b *LogBuffer) Lines() []string {
+	s := strings.TrimSuffix(b.String(), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// LoggedMatches is a Checker checking that the provided *LogBuffer, as
+// returned by C.CaptureLog, includes a line matching the provided regular
+// expression pattern. As with Matches, the pattern must match the whole line.
+// For instance:
+//
+//     c.Assert(logs, qt.LoggedMatches, "retrying after .*")
+//
+// On failure, all the logged lines are reported.
+var LoggedMatches Checker = &loggedMatchesChecker{
+	numArgs: 1,
+}
+
+type loggedMatchesChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got is a log buffer with
+// a line matching args[0].
+func (c *loggedMatchesChecker) Check(got interface{}, args []interface{}) error {
+	lines, line, err := c.match(got, args[0])
+	if err != nil {
+		return err
+	}
+	if line >= 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("no logged line matches the pattern:\n(pattern)\n\t%q\n(log)", args[0])
+	if len(lines) == 0 {
+		return fmt.Errorf("%s\n\t<empty>", msg)
+	}
+	for _, l := range lines {
+		msg += fmt.Sprintf("\n\t%q", l)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// Negate implements Checker.Negate by checking that got is a log buffer
+// without lines matching args[0].
+func (c *loggedMatchesChecker) Negate(got interface{}, args []interface{}) error {
+	lines, line, err := c.match(got, args[0])
+	if err != nil {
+		return err
+	}
+	if line < 0 {
+		return nil
+	}
+	return fmt.Errorf("logged line matches the pattern, but should not:\n(line)\n\t%q\n(pattern)\n\t%q", lines[line], args[0])
+}
+
+// match returns the lines logged in got, and the index of the first line
+// matching the given pattern, or -1 if no lines match.
+func (c *loggedMatchesChecker) match(got, pattern interface{}) ([]string, int, error) {
+	b, ok := got.(*LogBuffer)
+	if !ok || b == nil {
+		return nil, -1, BadCheckf("expected a non-nil *quicktest.LogBuffer, got %T instead", got)
+	}
+	// Check the pattern even if nothing has been logged.
+	if err := match("", pattern, ""); IsBadCheck(err) {
+		return nil, -1, err
+	}
+	lines := b.Lines()
+	for i, line := range lines {
+		if match(line, pattern, "") == nil {
+			return lines, i, nil
+		}
+	}
+	return lines, -1, nil
+}
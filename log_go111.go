@@ -0,0 +1,18 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+//go:build !go1.12
+// +build !go1.12
+
+package quicktest
+
+import (
+	"io"
+	"os"
+)
+
+// logWriter returns the output destination of the standard logger. The
+// standard logger output cannot be retrieved before Go 1.12, so its default
+// destination is assumed.
+func logWriter() io.Writer {
+	return os.Stderr
+}
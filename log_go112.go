@@ -0,0 +1,16 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+//go:build go1.12
+// +build go1.12
+
+package quicktest
+
+import (
+	"io"
+	"log"
+)
+
+// logWriter returns the output destination of the standard logger.
+func logWriter() io.Writer {
+	return log.Writer()
+}
@@ -0,0 +1,27 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+//go:build go1.12
+// +build go1.12
+
+package quicktest_test
+
+import (
+	"log"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCaptureLogRestoresOutput(t *testing.T) {
+	output := log.Writer()
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.CaptureLog()
+	if log.Writer() == output {
+		t.Fatalf("log output not captured")
+	}
+	tt.cleanup()
+	if log.Writer() != output {
+		t.Fatalf("log output not restored")
+	}
+}
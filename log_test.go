@@ -0,0 +1,104 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"io"
+	"log"
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCaptureLog(t *testing.T) {
+	flags := log.Flags()
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	logs := c.CaptureLog()
+	log.Print("bad wolf")
+	log.Printf("answer: %d", 42)
+	if got, want := logs.Lines(), []string{"bad wolf", "answer: 42"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected logged lines:\ngot  %q\nwant %q", got, want)
+	}
+	tt.cleanup()
+	if log.Flags() != flags {
+		t.Fatalf("log flags not restored: got %d, want %d", log.Flags(), flags)
+	}
+}
+
+// logBuffer returns a log buffer including the given output.
+func logBuffer(output string) *qt.LogBuffer {
+	b := &qt.LogBuffer{}
+	io.WriteString(b, output)
+	return b
+}
+
+var loggedMatchesTests = []struct {
+	about                 string
+	got                   func() interface{}
+	pattern               interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about: "match",
+	got: func() interface{} {
+		return logBuffer("connecting\nretrying after 1s\nconnected\n")
+	},
+	pattern:               "retrying after .*",
+	expectedNegateFailure: "logged line matches the pattern, but should not:\n(line)\n\t\"retrying after 1s\"\n(pattern)\n\t\"retrying after .*\"\n",
+}, {
+	about: "no match",
+	got: func() interface{} {
+		return logBuffer("connecting\nconnected\n")
+	},
+	pattern:              "retrying",
+	expectedCheckFailure: "no logged line matches the pattern:\n(pattern)\n\t\"retrying\"\n(log)\n\t\"connecting\"\n\t\"connected\"\n",
+}, {
+	about: "partial match",
+	got: func() interface{} {
+		return logBuffer("retrying after 1s\n")
+	},
+	pattern:              "retrying",
+	expectedCheckFailure: "no logged line matches the pattern:\n(pattern)\n\t\"retrying\"\n(log)\n\t\"retrying after 1s\"\n",
+}, {
+	about: "empty log",
+	got: func() interface{} {
+		return logBuffer("")
+	},
+	pattern:              ".*",
+	expectedCheckFailure: "no logged line matches the pattern:\n(pattern)\n\t\".*\"\n(log)\n\t<empty>\n",
+}, {
+	about: "invalid pattern",
+	got: func() interface{} {
+		return logBuffer("")
+	},
+	pattern:               "(",
+	expectedCheckFailure:  "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`\n",
+	expectedNegateFailure: "cannot compile regular expression \"(\": error parsing regexp: missing closing ): `^(()$`\n",
+}, {
+	about: "not a log buffer",
+	got: func() interface{} {
+		return "bad wolf"
+	},
+	pattern:               ".*",
+	expectedCheckFailure:  "expected a non-nil *quicktest.LogBuffer, got string instead\n",
+	expectedNegateFailure: "expected a non-nil *quicktest.LogBuffer, got string instead\n",
+}}
+
+func TestLoggedMatches(t *testing.T) {
+	for _, test := range loggedMatchesTests {
+		t.Run(test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.LoggedMatches, test.pattern)
+			checkResult(t, ok, tt.errorString(), test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Check(test.got(), qt.Not(qt.LoggedMatches), test.pattern)
+			checkResult(t, ok, tt.errorString(), test.expectedNegateFailure)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Option is an option that can be provided to New to configure the returned
+// checker. Subtests started with c.Run inherit the configuration.
+type Option func(c *C)
+
+// WithMaxReportLines returns an option setting the maximum number of lines of
+// the checker failure message included in reports. It is equivalent to
+// calling SetMaxReportLines on the checker.
+func WithMaxReportLines(n int) Option {
+	return func(c *C) {
+		c.SetMaxReportLines(n)
+	}
+}
+
+// WithMaxValueLen returns an option setting the maximum number of characters
+// of each value, that is each indented line, included in reports. It is
+// equivalent to calling SetMaxValueLen on the checker.
+func WithMaxValueLen(n int) Option {
+	return func(c *C) {
+		c.SetMaxValueLen(n)
+	}
+}
+
+// WithShowTypes returns an option setting whether the failure reports of
+// Equals-style checks include the Go types of the got and want values. It is
+// equivalent to calling SetShowTypes on the checker.
+func WithShowTypes(show bool) Option {
+	return func(c *C) {
+		c.SetShowTypes(show)
+	}
+}
+
+// WithLogStats returns an option setting whether the checks and assertions
+// stats are logged when the test completes. It is equivalent to calling
+// SetLogStats on the checker.
+func WithLogStats(log bool) Option {
+	return func(c *C) {
+		c.SetLogStats(log)
+	}
+}
+
+// WithContextLines returns an option setting the number of lines of code
+// shown before and after the failed check or assertion in failure reports.
+// Three lines are shown by default. Use zero to only show the statement
+// including the check.
+func WithContextLines(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid number of context lines: %d", n))
+	}
+	return func(c *C) {
+		c.contextLines = n
+	}
+}
+
+// WithFormat returns an option setting the function used to format the got
+// and want values reported by Equals-style failures, for instance:
+//
+//     not equal:
+//     (-got +want)
+//         -: 42
+//         +: 47
+//
+// This is useful when the default Go syntax representation of values is hard
+// to read, for instance:
+//
+//     c := qt.New(t, qt.WithFormat(func(v interface{}) string {
+//         return fmt.Sprintf("%+v", v)
+//     }))
+//
+// Failure messages of other checkers, including go-cmp diffs, are not
+// affected.
+func WithFormat(format func(v interface{}) string) Option {
+	return func(c *C) {
+		c.format = format
+	}
+}
+
+// WithColor returns an option setting whether the diffs included in failure
+// reports are colored using ANSI escape sequences, with removed lines in red
+// and added lines in green. Colors are disabled by default, as they are only
+// useful when the test output is displayed in a terminal.
+func WithColor(color bool) Option {
+	return func(c *C) {
+		c.color = color
+	}
+}
+
+// WithOnFailure returns an option registering f to be called with the
+// failure report whenever a check or assertion fails. It is equivalent to
+// calling OnFailure on the checker.
+func WithOnFailure(f func(report string)) Option {
+	return func(c *C) {
+		c.OnFailure(f)
+	}
+}
+
+// WithOutput returns an option setting a writer to which failure reports are
+// also written. It is equivalent to calling SetOutput on the checker.
+func WithOutput(w io.Writer) Option {
+	return func(c *C) {
+		c.SetOutput(w)
+	}
+}
+
+// WithCmpOptions returns an option registering compare options applied by
+// default to the checkers accepting them, like CmpEquals and DeepEquals. It is
+// equivalent to calling AddCmpOptions on the checker.
+func WithCmpOptions(opts ...cmp.Option) Option {
+	return func(c *C) {
+		c.AddCmpOptions(opts...)
+	}
+}
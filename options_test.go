@@ -0,0 +1,138 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestNewWithoutOptions(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check(42, qt.Equals, int64(42))
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: 42\n\t+: 42\n")
+}
+
+func TestWithShowTypes(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithShowTypes(true))
+	ok := c.Check(42, qt.Equals, int64(42))
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: 42 (int)\n\t+: 42 (int64)\n")
+}
+
+func TestWithShowTypesOtherCheckers(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithShowTypes(true))
+	ok := c.Check(42, qt.Not(qt.Equals), 42)
+	checkResult(t, ok, tt.errorString(), "both values equal 42, but should not\n")
+}
+
+func TestWithFormat(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithFormat(func(v interface{}) string {
+		return fmt.Sprintf("<%v>", v)
+	}))
+	ok := c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: <42>\n\t+: <47>\n")
+}
+
+func TestWithFormatAndShowTypes(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithFormat(func(v interface{}) string {
+		return fmt.Sprintf("<%v>", v)
+	}), qt.WithShowTypes(true))
+	ok := c.Check(42, qt.Equals, int64(42))
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: <42> (int)\n\t+: <42> (int64)\n")
+}
+
+func TestWithColor(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithColor(true))
+	ok := c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\x1b[31m\t-: 42\x1b[0m\n\x1b[32m\t+: 47\x1b[0m\n")
+}
+
+func TestWithMaxReportLines(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithMaxReportLines(2))
+	ok := c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n... (2 more lines)\n")
+}
+
+func TestWithMaxReportLinesInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "invalid maximum number of report lines: -1" {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	qt.New(&testingT{}, qt.WithMaxReportLines(-1))
+}
+
+func TestWithMaxValueLen(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithMaxValueLen(6))
+	ok := c.Check("bad wolf", qt.Equals, "b")
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: \"ba… (truncated, 7 more)\n\t+: \"b\"\n")
+}
+
+func TestWithMaxValueLenInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "invalid maximum value length: -1" {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	qt.New(&testingT{}, qt.WithMaxValueLen(-1))
+}
+
+func TestWithContextLinesInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "invalid number of context lines: -1" {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	qt.WithContextLines(-1)
+}
+
+func TestWithOnFailure(t *testing.T) {
+	tt := &testingT{}
+	var reports []string
+	c := qt.New(tt, qt.WithOnFailure(func(report string) {
+		reports = append(reports, report)
+	}))
+	ok := c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n")
+	if len(reports) != 1 || reports[0] != tt.errorString() {
+		t.Fatalf("unexpected reports: %q", reports)
+	}
+}
+
+func TestWithCmpOptions(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithCmpOptions(cmpopts.EquateEmpty()))
+	ok := c.Check([]string{}, qt.DeepEquals, []string(nil))
+	checkResult(t, ok, tt.errorString(), "")
+}
+
+func TestMultipleOptions(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithShowTypes(true), qt.WithMaxReportLines(3))
+	ok := c.Check(42, qt.Equals, int64(42))
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: 42 (int)\n... (1 more lines)\n")
+}
+
+func TestWithOutput(t *testing.T) {
+	tt := &testingT{}
+	var buf bytes.Buffer
+	c := qt.New(tt, qt.WithOutput(&buf))
+	ok := c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n")
+	if buf.String() != tt.errorString() {
+		t.Fatalf("unexpected output:\ngot  %q\nwant %q", buf.String(), tt.errorString())
+	}
+}
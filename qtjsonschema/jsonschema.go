@@ -0,0 +1,84 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+// Package qtjsonschema provides quicktest checkers for validating JSON
+// documents against a JSON Schema. It lives in its own package so that the
+// core quicktest package does not depend on the JSON Schema library.
+package qtjsonschema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// Matches returns a Checker checking that the provided JSON document, as a
+// string or a []byte, is valid according to the given JSON Schema.
+// For instance:
+//
+//     c.Assert(body, qtjsonschema.Matches(`{
+//         "type": "object",
+//         "required": ["name"]
+//     }`))
+//
+func Matches(schema string) qt.Checker {
+	return &matchesChecker{
+		schema: schema,
+	}
+}
+
+type matchesChecker struct {
+	schema string
+}
+
+// Check implements Checker.Check by checking that got is a JSON document
+// satisfying the stored schema.
+func (c *matchesChecker) Check(got interface{}, args []interface{}) error {
+	data, ok := documentBytes(got)
+	if !ok {
+		return qt.BadCheckf("expected a JSON string or []byte, got %T instead", got)
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(c.schema))
+	if err != nil {
+		return qt.BadCheckf("invalid JSON schema: %s", err)
+	}
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return qt.BadCheckf("cannot parse provided JSON: %s", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "JSON document does not match the schema:\n(value)\n\t%s\n(errors)", data)
+	for _, e := range result.Errors() {
+		fmt.Fprintf(&buf, "\n\t%s: %s", e.Field(), e.Description())
+	}
+	return errors.New(buf.String())
+}
+
+// Negate implements Checker.Negate by checking that got is a JSON document
+// not satisfying the stored schema.
+func (c *matchesChecker) Negate(got interface{}, args []interface{}) error {
+	return qt.NegateCheckf(c.Check(got, args), "JSON document matches the schema:\n(value)\n\t%s", got)
+}
+
+// NumArgs implements Checker.NumArgs.
+func (c *matchesChecker) NumArgs() int {
+	return 0
+}
+
+// documentBytes returns the given document as a []byte. The ok return value
+// is false if v is neither a string nor a []byte.
+func documentBytes(v interface{}) (data []byte, ok bool) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	}
+	return nil, false
+}
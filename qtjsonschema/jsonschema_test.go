@@ -0,0 +1,98 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package qtjsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest/qtjsonschema"
+)
+
+const personSchema = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"age": {"type": "integer", "minimum": 0}
+	},
+	"required": ["name"]
+}`
+
+var matchesTests = []struct {
+	about                 string
+	schema                string
+	got                   interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about:  "valid document",
+	schema: personSchema,
+	got:    `{"name": "bad wolf", "age": 42}`,
+	expectedNegateFailure: "JSON document matches the schema, but should not:\n(value)\n\t{\"name\": \"bad wolf\", \"age\": 42}",
+}, {
+	about:  "valid document as bytes",
+	schema: personSchema,
+	got:    []byte(`{"name": "dalek"}`),
+	expectedNegateFailure: "JSON document matches the schema, but should not:\n(value)\n\t{\"name\": \"dalek\"}",
+}, {
+	about:                "invalid document",
+	schema:               personSchema,
+	got:                  `{"age": -1}`,
+	expectedCheckFailure: "JSON document does not match the schema:\n(value)\n\t{\"age\": -1}\n(errors)\n\t",
+}, {
+	about:                "invalid field",
+	schema:               personSchema,
+	got:                  `{"name": 42}`,
+	expectedCheckFailure: "JSON document does not match the schema:\n(value)\n\t{\"name\": 42}\n(errors)\n\tname: Invalid type. Expected: string, given: integer",
+}, {
+	about:                 "invalid JSON",
+	schema:                personSchema,
+	got:                   `{"name":`,
+	expectedCheckFailure:  "cannot parse provided JSON: ",
+	expectedNegateFailure: "cannot parse provided JSON: ",
+}, {
+	about:                 "invalid schema",
+	schema:                `{"type": 42}`,
+	got:                   `{}`,
+	expectedCheckFailure:  "invalid JSON schema: ",
+	expectedNegateFailure: "invalid JSON schema: ",
+}, {
+	about:                 "not a JSON document",
+	schema:                personSchema,
+	got:                   42,
+	expectedCheckFailure:  "expected a JSON string or []byte, got int instead",
+	expectedNegateFailure: "expected a JSON string or []byte, got int instead",
+}}
+
+func TestMatches(t *testing.T) {
+	for _, test := range matchesTests {
+		t.Run(test.about, func(t *testing.T) {
+			err := qtjsonschema.Matches(test.schema).Check(test.got, nil)
+			assertErrHasPrefix(t, err, test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			err := qtjsonschema.Matches(test.schema).Negate(test.got, nil)
+			assertErrHasPrefix(t, err, test.expectedNegateFailure)
+		})
+	}
+}
+
+// assertErrHasPrefix fails if err does not start with the given prefix, or if
+// it is not nil when the prefix is empty.
+func assertErrHasPrefix(t testing.TB, err error, prefix string) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if prefix == "" {
+		if err != nil {
+			t.Fatalf("error:\ngot  %q\nwant nil", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("error:\ngot  nil\nwant %q", prefix)
+	}
+	if !strings.HasPrefix(err.Error(), prefix) {
+		t.Fatalf("prefix:\ngot  %q\nwant %q", err, prefix)
+	}
+}
@@ -0,0 +1,68 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+// Package qtvalidator provides quicktest checkers for values validated with
+// the go-playground validator package. It lives in its own package so that
+// the core quicktest package does not depend on the validator library.
+package qtvalidator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/go-playground/validator.v9"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// IsValid is a Checker checking that the provided struct, or pointer to
+// struct, satisfies the constraints defined by its "validate" field tags, as
+// interpreted by the go-playground validator package.
+// For instance:
+//
+//     type Request struct {
+//         Name  string `validate:"required"`
+//         Email string `validate:"required,email"`
+//     }
+//
+//     c.Assert(req, qtvalidator.IsValid)
+//
+var IsValid qt.Checker = &isValidChecker{}
+
+type isValidChecker struct{}
+
+// validate holds the validator used by IsValid. It caches struct information
+// and is safe for concurrent use.
+var validate = validator.New()
+
+// Check implements Checker.Check by checking that got is a valid struct.
+func (c *isValidChecker) Check(got interface{}, args []interface{}) error {
+	err := validate.Struct(got)
+	if err == nil {
+		return nil
+	}
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return qt.BadCheckf("cannot validate value: %s", err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "value is not valid:\n(value)\n\t%#v\n(violations)", got)
+	for _, e := range verrs {
+		fmt.Fprintf(&buf, "\n\t%s: failed on the %q tag", e.Namespace(), e.Tag())
+		if param := e.Param(); param != "" {
+			fmt.Fprintf(&buf, " with parameter %q", param)
+		}
+	}
+	return errors.New(buf.String())
+}
+
+// Negate implements Checker.Negate by checking that got is a struct not
+// satisfying its validation constraints.
+func (c *isValidChecker) Negate(got interface{}, args []interface{}) error {
+	return qt.NegateCheckf(c.Check(got, args), "value is valid:\n(value)\n\t%#v", got)
+}
+
+// NumArgs implements Checker.NumArgs.
+func (c *isValidChecker) NumArgs() int {
+	return 0
+}
@@ -0,0 +1,76 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package qtvalidator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest/qtvalidator"
+)
+
+type validatedPerson struct {
+	Name string `validate:"required"`
+	Age  int    `validate:"min=0,max=130"`
+}
+
+var isValidTests = []struct {
+	about                 string
+	got                   interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about: "valid struct",
+	got:   validatedPerson{Name: "bad wolf", Age: 42},
+	expectedNegateFailure: "value is valid, but should not:\n(value)\n\tqtvalidator_test.validatedPerson{Name:\"bad wolf\", Age:42}",
+}, {
+	about: "valid struct pointer",
+	got:   &validatedPerson{Name: "dalek"},
+	expectedNegateFailure: "value is valid, but should not:\n(value)\n\t&qtvalidator_test.validatedPerson{Name:\"dalek\", Age:0}",
+}, {
+	about:                "missing required field",
+	got:                  validatedPerson{Age: 42},
+	expectedCheckFailure: "value is not valid:\n(value)\n\tqtvalidator_test.validatedPerson{Name:\"\", Age:42}\n(violations)\n\tvalidatedPerson.Name: failed on the \"required\" tag",
+}, {
+	about:                "multiple violations",
+	got:                  validatedPerson{Age: 200},
+	expectedCheckFailure: "value is not valid:\n(value)\n\tqtvalidator_test.validatedPerson{Name:\"\", Age:200}\n(violations)\n\tvalidatedPerson.Name: failed on the \"required\" tag\n\tvalidatedPerson.Age: failed on the \"max\" tag with parameter \"130\"",
+}, {
+	about:                 "not a struct",
+	got:                   42,
+	expectedCheckFailure:  "cannot validate value: validator: (nil int)",
+	expectedNegateFailure: "cannot validate value: validator: (nil int)",
+}}
+
+func TestIsValid(t *testing.T) {
+	for _, test := range isValidTests {
+		t.Run(test.about, func(t *testing.T) {
+			err := qtvalidator.IsValid.Check(test.got, nil)
+			assertErrHasPrefix(t, err, test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			err := qtvalidator.IsValid.Negate(test.got, nil)
+			assertErrHasPrefix(t, err, test.expectedNegateFailure)
+		})
+	}
+}
+
+// assertErrHasPrefix fails if err does not start with the given prefix, or if
+// it is not nil when the prefix is empty.
+func assertErrHasPrefix(t testing.TB, err error, prefix string) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if prefix == "" {
+		if err != nil {
+			t.Fatalf("error:\ngot  %q\nwant nil", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("error:\ngot  nil\nwant %q", prefix)
+	}
+	if !strings.HasPrefix(err.Error(), prefix) {
+		t.Fatalf("prefix:\ngot  %q\nwant %q", err, prefix)
+	}
+}
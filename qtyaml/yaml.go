@@ -0,0 +1,83 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+// Package qtyaml provides quicktest checkers for YAML documents. It lives in
+// its own package so that the core quicktest package does not depend on the
+// YAML library.
+package qtyaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// Equals is a Checker checking that the provided YAML document, as a string
+// or a []byte, is semantically equal to the expected value. The expected
+// value can be either a YAML document, again as a string or a []byte, or any
+// Go value, in which case it is marshaled to YAML first. Both sides are
+// decoded into generic values before being compared, so that formatting and
+// map key ordering are not relevant.
+// For instance:
+//
+//     c.Assert(config, qtyaml.Equals, "name: bad wolf\nanswer: 42\n")
+//     c.Assert(config, qtyaml.Equals, map[string]int{"answer": 42})
+//
+var Equals qt.Checker = &equalsChecker{}
+
+type equalsChecker struct{}
+
+// Check implements Checker.Check by checking that got and args[0] decode to
+// the same YAML values.
+func (c *equalsChecker) Check(got interface{}, args []interface{}) error {
+	gotData, ok := documentBytes(got)
+	if !ok {
+		return qt.BadCheckf("expected a YAML string or []byte, got %T instead", got)
+	}
+	var gotValue interface{}
+	if err := yaml.Unmarshal(gotData, &gotValue); err != nil {
+		return qt.BadCheckf("cannot unmarshal provided YAML: %s", err)
+	}
+	want := args[0]
+	wantData, ok := documentBytes(want)
+	if !ok {
+		var err error
+		if wantData, err = yaml.Marshal(want); err != nil {
+			return qt.BadCheckf("cannot marshal expected value to YAML: %s", err)
+		}
+	}
+	var wantValue interface{}
+	if err := yaml.Unmarshal(wantData, &wantValue); err != nil {
+		return qt.BadCheckf("cannot unmarshal expected YAML: %s", err)
+	}
+	// Decoded YAML values only include maps, slices and basic types, which
+	// can always be compared by go-cmp.
+	if diff := qt.Diff(gotValue, wantValue); diff != "" {
+		return fmt.Errorf("YAML values are not equal:\n(-got +want)\n%s", diff)
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] do not
+// decode to the same YAML values.
+func (c *equalsChecker) Negate(got interface{}, args []interface{}) error {
+	return qt.NegateCheckf(c.Check(got, args), "YAML values are equal:\n(value)\n\t%s", got)
+}
+
+// NumArgs implements Checker.NumArgs.
+func (c *equalsChecker) NumArgs() int {
+	return 1
+}
+
+// documentBytes returns the given document as a []byte. The ok return value
+// is false if v is neither a string nor a []byte.
+func documentBytes(v interface{}) (data []byte, ok bool) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	}
+	return nil, false
+}
@@ -0,0 +1,86 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package qtyaml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest/qtyaml"
+)
+
+var equalsTests = []struct {
+	about                 string
+	got                   interface{}
+	want                  interface{}
+	expectedCheckFailure  string
+	expectedNegateFailure string
+}{{
+	about: "same documents",
+	got:   "name: bad wolf\nanswer: 42\n",
+	want:  []byte("answer: 42\nname: 'bad wolf'"),
+	expectedNegateFailure: "YAML values are equal, but should not:\n(value)\n\tname: bad wolf\n",
+}, {
+	about: "document and Go value",
+	got:   []byte("ints: [42, 47]\n"),
+	want: map[string][]int{
+		"ints": {42, 47},
+	},
+	expectedNegateFailure: "YAML values are equal, but should not:\n",
+}, {
+	about:                "different documents",
+	got:                  "name: bad wolf\n",
+	want:                 "name: dalek\n",
+	expectedCheckFailure: "YAML values are not equal:\n(-got +want)\n",
+}, {
+	about:                 "invalid provided YAML",
+	got:                   "name: [",
+	want:                  "name: dalek\n",
+	expectedCheckFailure:  "cannot unmarshal provided YAML: ",
+	expectedNegateFailure: "cannot unmarshal provided YAML: ",
+}, {
+	about:                 "invalid expected YAML",
+	got:                   "name: bad wolf\n",
+	want:                  "{",
+	expectedCheckFailure:  "cannot unmarshal expected YAML: ",
+	expectedNegateFailure: "cannot unmarshal expected YAML: ",
+}, {
+	about:                 "not a YAML document",
+	got:                   42,
+	want:                  "answer: 42\n",
+	expectedCheckFailure:  "expected a YAML string or []byte, got int instead",
+	expectedNegateFailure: "expected a YAML string or []byte, got int instead",
+}}
+
+func TestEquals(t *testing.T) {
+	for _, test := range equalsTests {
+		t.Run(test.about, func(t *testing.T) {
+			err := qtyaml.Equals.Check(test.got, []interface{}{test.want})
+			assertErrHasPrefix(t, err, test.expectedCheckFailure)
+		})
+		t.Run("Not "+test.about, func(t *testing.T) {
+			err := qtyaml.Equals.Negate(test.got, []interface{}{test.want})
+			assertErrHasPrefix(t, err, test.expectedNegateFailure)
+		})
+	}
+}
+
+// assertErrHasPrefix fails if err does not start with the given prefix, or if
+// it is not nil when the prefix is empty.
+func assertErrHasPrefix(t testing.TB, err error, prefix string) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if prefix == "" {
+		if err != nil {
+			t.Fatalf("error:\ngot  %q\nwant nil", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("error:\ngot  nil\nwant %q", prefix)
+	}
+	if !strings.HasPrefix(err.Error(), prefix) {
+		t.Fatalf("prefix:\ngot  %q\nwant %q", err, prefix)
+	}
+}
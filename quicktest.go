@@ -4,13 +4,18 @@ package quicktest
 
 import (
 	"fmt"
+	"io"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 // New returns a new checker instance that uses t to fail the test when checks
-// fail. It only ever calls the Fatal, Error and (when available) Run methods
-// of t. For instance.
+// fail. It only ever calls the Fatal, Error and (when available) Helper, Run,
+// Cleanup and Log methods of t. For instance.
 //
 //     func TestFoo(t *testing.T) {
 //         t.Run("A=42", func(t *testing.T) {
@@ -19,12 +24,35 @@ import (
 //         })
 //     }
 //
+// Since t is a testing.TB, both *testing.T and *testing.B values can be used,
+// so that checks can also be performed in benchmarks. For instance:
+//
+//     func BenchmarkFoo(b *testing.B) {
+//         c := qt.New(b)
+//         for i := 0; i < b.N; i++ {
+//             c.Assert(foo(), qt.IsNil)
+//         }
+//     }
+//
+// Note that Run is not supported when t is a *testing.B.
+//
+// The checker can be configured by providing options, for instance:
+//
+//     c := qt.New(t, qt.WithShowTypes(true), qt.WithContextLines(5))
+//
 // The library already provides some base checkers, and more can be added by
 // implementing the Checker interface.
-func New(t testing.TB) *C {
-	return &C{
-		TB: t,
+func New(t testing.TB, opts ...Option) *C {
+	c := &C{
+		TB:             t,
+		maxReportLines: defaultMaxReportLines,
+		contextLines:   defaultContextLines,
+		stats:          &statsCounter{},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // C is a quicktest checker. It embeds a testing.TB value and provides
@@ -32,6 +60,209 @@ func New(t testing.TB) *C {
 // uses the wrapped TB value to fail the test appropriately.
 type C struct {
 	testing.TB
+
+	// maxReportLines holds the maximum number of lines of the checker
+	// failure message included in reports. Zero means no limit.
+	maxReportLines int
+
+	// maxValueLen holds the maximum number of characters of each value, that
+	// is each indented line of the checker failure message, included in
+	// reports. Zero means no limit.
+	maxValueLen int
+
+	// showTypes holds whether the types of the got and want values are
+	// included in the failure reports of Equals-style checks.
+	showTypes bool
+
+	// contextLines holds the number of lines of code shown before and after
+	// the failed statement in failure reports.
+	contextLines int
+
+	// format holds the function used to format the got and want values in
+	// failure reports, or nil if the default formatting is used.
+	format func(v interface{}) string
+
+	// color holds whether the diffs in failure reports are colored.
+	color bool
+
+	// comments holds the comments included in all failure reports, before
+	// the comment provided to the check, if any.
+	comments []Comment
+
+	// stats holds the counters of checks and assertions run in the current
+	// test. It is shared by all the checkers created for the same test.
+	stats *statsCounter
+
+	// logStats holds whether stats are logged when the test completes.
+	logStats bool
+
+	// onFailure holds the functions called with the report of each failure,
+	// in registration order.
+	onFailure []func(report string)
+
+	// output holds the writer to which failure reports are also written, if
+	// any. It is shared by all the checkers derived from the one on which
+	// SetOutput has been called.
+	output *syncWriter
+
+	// cmpOptions holds the compare options applied by default to the checkers
+	// accepting them, like CmpEquals and DeepEquals.
+	cmpOptions []cmp.Option
+}
+
+// SetMaxReportLines sets the maximum number of lines of the checker failure
+// message, for instance a go-cmp diff, included in failure reports. Longer
+// messages are truncated, and the number of omitted lines is reported.
+// The limit is 1000 lines by default. Use zero to disable truncation.
+// Subtests started with c.Run inherit this setting.
+func (c *C) SetMaxReportLines(n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid maximum number of report lines: %d", n))
+	}
+	c.maxReportLines = n
+}
+
+// SetMaxValueLen sets the maximum number of characters of each value
+// included in failure reports, so that huge values, for instance rendered
+// HTML pages, do not flood the output. Values are reported on their own
+// indented lines, so the limit applies to each indented line of the checker
+// failure message, excluding its indentation. Longer values are truncated and
+// the number of omitted characters is reported, for instance:
+//
+//     (value)
+//         "<html><head><title>bad… (truncated, 4242 more)
+//
+// Only the head of each value is kept: it is where the diff markers and the
+// type of the value are found, while the tail of long values is usually made
+// of closing delimiters, and a truncated value can always be inspected in
+// full by disabling truncation.
+//
+// Values are not truncated by default. Use zero to disable truncation.
+// Subtests started with c.Run inherit this setting.
+func (c *C) SetMaxValueLen(n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid maximum value length: %d", n))
+	}
+	c.maxValueLen = n
+}
+
+// SetShowTypes sets whether the failure reports of Equals-style checks, which
+// report the got and want values in a "(-got +want)" section, include the Go
+// types of those values alongside the values themselves, for instance:
+//
+//     not equal:
+//     (-got +want)
+//         -: 42 (int)
+//         +: 42 (int64)
+//
+// This is useful when values of different types are formatted the same way.
+// Failure reports of other checkers, including go-cmp diffs, are not affected.
+// Type annotations are disabled by default.
+// Subtests started with c.Run inherit this setting.
+func (c *C) SetShowTypes(show bool) {
+	c.showTypes = show
+}
+
+// Stats returns the number of checks and assertions run so far in the
+// current test, and how many of them failed. Checks and assertions run within
+// CheckAll and AssertAll, or using checkers returned by WithComment, are
+// included, while the ones run in subtests started with c.Run are not.
+func (c *C) Stats() Stats {
+	return c.stats.get()
+}
+
+// SetLogStats sets whether the checks and assertions stats are logged when the
+// test completes, for instance:
+//
+//     quicktest: 5 checks, 2 assertions: 6 passed, 1 failed
+//
+// This is useful to confirm that data driven tests exercised the expected
+// number of cases. Logging is disabled by default, and it requires the
+// underlying TB to support Cleanup. Disabling logging after enabling it
+// prevents the stats from being logged.
+// Subtests started with c.Run inherit this setting.
+func (c *C) SetLogStats(log bool) {
+	c.logStats = log
+	c.stats.setLog(c.TB, log)
+}
+
+// WithComment returns a new checker, sharing the underlying TB and the
+// configuration of c, which includes a comment formatted according to the
+// given format specifier and args in all its failure reports. For instance:
+//
+//     for i, test := range tests {
+//         c := c.WithComment("test %d: %s", i, test.about)
+//         c.Assert(test.got, qt.Equals, test.want)
+//     }
+//
+// The comment is displayed before the one provided to the failed check, if
+// any. Calling WithComment on the returned checker adds further comments,
+// while c itself is not modified, so that it is safe to derive checkers from c
+// in concurrent subtests. Subtests started with c.Run inherit the comments.
+func (c *C) WithComment(format string, args ...interface{}) *C {
+	child := c.newChild(c.TB)
+	comments := make([]Comment, len(c.comments), len(c.comments)+1)
+	copy(comments, c.comments)
+	child.comments = append(comments, Commentf(format, args...))
+	return child
+}
+
+// SetOutput sets a writer to which failure reports are written, in addition
+// to being reported to the underlying TB as usual. This can be used, for
+// instance, to collect all failure reports into a file for later inspection:
+//
+//     c.SetOutput(reportsFile)
+//
+// Writes are serialized, so that the same writer can be safely shared by
+// parallel subtests. Use a nil writer to stop writing reports. Checkers
+// returned by WithComment and subtests started with c.Run inherit this
+// setting.
+func (c *C) SetOutput(w io.Writer) {
+	if w == nil {
+		c.output = nil
+		return
+	}
+	c.output = &syncWriter{
+		w: w,
+	}
+}
+
+// OnFailure registers f to be called with the failure report whenever a check
+// or assertion executed by c fails, before the failure is reported to the
+// underlying TB. This can be used to collect additional diagnostics, like
+// logs or screenshots, when something goes wrong. For instance:
+//
+//     c.OnFailure(func(report string) {
+//         c.Logf("server logs:\n%s", srv.Logs())
+//     })
+//
+// Multiple functions can be registered, and they are called in registration
+// order. Within CheckAll and AssertAll, functions are called for each failed
+// check. Checkers returned by WithComment and subtests started with c.Run
+// inherit the functions registered so far.
+func (c *C) OnFailure(f func(report string)) {
+	onFailure := make([]func(report string), len(c.onFailure), len(c.onFailure)+1)
+	copy(onFailure, c.onFailure)
+	c.onFailure = append(onFailure, f)
+}
+
+// AddCmpOptions registers compare options applied by default to all the
+// checkers accepting them, like CmpEquals and DeepEquals, when used in checks
+// and assertions executed by c. This avoids repeating the same options in all
+// the comparisons of a test. For instance:
+//
+//     c.AddCmpOptions(cmpopts.IgnoreFields(User{}, "CreatedAt"))
+//     c.Assert(got, qt.DeepEquals, want)
+//
+// The options are used in addition to the ones stored in the checker and the
+// ones provided to the check. Only checks executed through c are affected:
+// other checkers created with New, even for the same test, are not.
+// Checkers returned by WithComment and subtests started with c.Run inherit the
+// options registered so far.
+func (c *C) AddCmpOptions(opts ...cmp.Option) {
+	cmpOptions := make([]cmp.Option, len(c.cmpOptions), len(c.cmpOptions)+len(opts))
+	copy(cmpOptions, c.cmpOptions)
+	c.cmpOptions = append(cmpOptions, opts...)
 }
 
 // Check runs the given check and continues execution in case of failure.
@@ -43,7 +274,11 @@ type C struct {
 // Additional args (not consumed by the checker), when provided, are included
 // as comments in the failure output when the check fails.
 func (c *C) Check(got interface{}, checker Checker, args ...interface{}) bool {
-	return check(c.TB.Error, checker, got, args)
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	c.stats.add(checkKind)
+	return c.check(c.TB.Error, checker, got, args)
 }
 
 // Assert runs the given check and stops execution in case of failure.
@@ -55,7 +290,167 @@ func (c *C) Check(got interface{}, checker Checker, args ...interface{}) bool {
 // Additional args (not consumed by the checker), when provided, are included
 // as comments in the failure output when the check fails.
 func (c *C) Assert(got interface{}, checker Checker, args ...interface{}) bool {
-	return check(c.TB.Fatal, checker, got, args)
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	c.stats.add(assertKind)
+	return c.check(c.TB.Fatal, checker, got, args)
+}
+
+// Checkf is like Check, but it also accepts a format specifier and its
+// arguments, which are used to build a comment displayed in case of failure.
+// The format specifier must be provided right after the arguments consumed by
+// the checker, so that the call shape is:
+//
+//     c.Checkf(got, checker, checkerArgs..., format, formatArgs...)
+//
+// For instance:
+//
+//     c.Checkf(answer, qt.Equals, 42, "iteration %d", i)
+//     c.Checkf(err, qt.IsNil, "cannot open %q", name)
+//
+// This is equivalent to providing a qt.Commentf(format, formatArgs...) value
+// as the last argument to Check.
+func (c *C) Checkf(got interface{}, checker Checker, args ...interface{}) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	c.stats.add(checkKind)
+	return c.checkf(c.TB.Error, checker, got, args)
+}
+
+// Assertf is like Assert, but it also accepts a format specifier and its
+// arguments, which are used to build a comment displayed in case of failure.
+// See Checkf for a description of the call shape.
+// For instance:
+//
+//     c.Assertf(got, qt.DeepEquals, []int{42, 47}, "numbers for %s", name)
+//
+func (c *C) Assertf(got interface{}, checker Checker, args ...interface{}) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	c.stats.add(assertKind)
+	return c.checkf(c.TB.Fatal, checker, got, args)
+}
+
+// checkf converts the format specifier and arguments following the checker
+// arguments into a comment, and then performs the check.
+func (c *C) checkf(fail func(...interface{}), checker Checker, got interface{}, args []interface{}) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	if checkerIsNil(checker) {
+		return c.check(fail, checker, got, args)
+	}
+	checker, args = withCallOptions(checker, args)
+	if len(args) <= checker.NumArgs() {
+		return c.check(fail, checker, got, args)
+	}
+	if isVariadic(checker) {
+		c.fail(fail, c.report(BadCheckf("cannot use a comment format with variadic checker %T: use Commentf instead", checker), Comment{}))
+		return false
+	}
+	n := checker.NumArgs()
+	format, ok := args[n].(string)
+	if !ok {
+		c.fail(fail, c.report(BadCheckf("comment format must be a string, got %T instead", args[n]), Comment{}))
+		return false
+	}
+	checkerArgs := make([]interface{}, n, n+1)
+	copy(checkerArgs, args)
+	return c.check(fail, checker, got, append(checkerArgs, Commentf(format, args[n+1:]...)))
+}
+
+// CheckAll runs f, collecting the failures of all the checks and assertions
+// executed by the provided checker, then reports them all together and
+// continues execution in case of failure. Inside f, failed assertions do not
+// stop execution, so that all mismatches are reported at once. For instance:
+//
+//     c.CheckAll(func(c *qt.C) {
+//         c.Assert(got.Name, qt.Equals, "bad wolf")
+//         c.Assert(got.Answer, qt.Equals, 42)
+//     })
+//
+// Subtests started with c.Run inside f are run as usual, and their failures
+// are reported by the subtests themselves rather than collected.
+// CheckAll reports whether all the checks succeeded.
+func (c *C) CheckAll(f func(c *C)) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	return c.collect(c.TB.Error, f)
+}
+
+// AssertAll is like CheckAll, but it stops execution if any of the checks
+// and assertions executed by f fails.
+func (c *C) AssertAll(f func(c *C)) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	return c.collect(c.TB.Fatal, f)
+}
+
+// AssertGroup runs f, deferring the effect of the failed assertions executed
+// by the provided checker until f returns. Inside f, a failed assertion does
+// not stop execution, so that related assertions, for instance in setup code,
+// are all run. Failed checks are reported as usual. When f returns, execution
+// is stopped if any of the assertions failed, and all their failures are
+// reported together. For instance:
+//
+//     c.AssertGroup(func(c *qt.C) {
+//         c.Assert(err, qt.IsNil)
+//         c.Assert(db.Ping(), qt.IsNil)
+//         c.Check(cfg.Debug, qt.Equals, false)
+//     })
+//
+// Unlike AssertAll, failed checks do not cause execution to stop. If f panics,
+// the assertion failures collected so far are still reported, as errors, and
+// the panic is propagated. Subtests started with c.Run inside f are run as
+// usual, and their failures are reported by the subtests themselves.
+// AssertGroup reports whether all the checks and assertions succeeded.
+func (c *C) AssertGroup(f func(c *C)) (ok bool) {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	g := &groupCollector{
+		TB: c.TB,
+	}
+	completed := false
+	defer func() {
+		if len(g.failures) == 0 {
+			ok = !g.failed
+			return
+		}
+		msg := fmt.Sprintf("\n%d assertion(s) failed:\n%s", len(g.failures), strings.Join(g.failures, ""))
+		if !completed {
+			// Do not stop execution while f is panicking, so that the panic
+			// is not swallowed.
+			c.TB.Error(msg)
+			return
+		}
+		c.TB.Fatal(msg)
+	}()
+	f(c.newChild(g))
+	completed = true
+	return false
+}
+
+// collect runs f with a checker collecting failures, and reports them using
+// the provided fail function.
+func (c *C) collect(fail func(...interface{}), f func(c *C)) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	col := &collector{
+		TB: c.TB,
+	}
+	f(c.newChild(col))
+	if len(col.failures) == 0 {
+		return true
+	}
+	fail(fmt.Sprintf("\n%d check(s) failed:\n%s", len(col.failures), strings.Join(col.failures, "")))
+	return false
 }
 
 // Run runs f as a subtest of t called name. It's a wrapper around
@@ -72,55 +467,215 @@ func (c *C) Assert(got interface{}, checker Checker, args ...interface{}) bool {
 // A panic is raised when Run is called and the embedded concrete type does not
 // implement Run, for instance if TB's concrete type is a benchmark.
 func (c *C) Run(name string, f func(c *C)) bool {
-	if r, ok := c.TB.(runner); ok {
-		return r.Run(name, func(t *testing.T) {
-			f(New(t))
-		})
-	}
-	panic(fmt.Sprintf("cannot execute Run with underlying concrete type %T", c.TB))
+	return run(c.TB, name, func(t *testing.T) {
+		child := c.newChild(t)
+		child.stats = &statsCounter{}
+		child.stats.setLog(t, child.logStats)
+		f(child)
+	})
+}
+
+// Results returns the provided values as a slice. It can be used to check all
+// the values returned by a function call at once, as Go allows passing
+// multiple return values directly to a variadic function, but not to Check or
+// Assert, which also require a checker. For instance:
+//
+//     c.Assert(qt.Results(strconv.Atoi("42")), qt.DeepEquals, []interface{}{42, nil})
+//
+// Note that nil values, including nil errors, are stored as untyped nil
+// interface values, so that they can be compared with a plain nil.
+func Results(values ...interface{}) []interface{} {
+	return values
+}
+
+// newChild returns a new checker using t to fail the test and sharing the
+// configuration of c.
+func (c *C) newChild(t testing.TB) *C {
+	child := *c
+	child.TB = t
+	return &child
 }
 
 // check performs the actual check by calling the provided fail function.
-func check(fail func(...interface{}), checker Checker, got interface{}, args []interface{}) bool {
-	// Ensure that we have a checker.
-	if checker == nil {
-		fail(report(BadCheckf("cannot run test: nil checker provided"), Comment{}))
+func (c *C) check(fail func(...interface{}), checker Checker, got interface{}, args []interface{}) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	// Unwrap values tagged with their source location, which is included
+	// in the failure report.
+	if v, ok := got.(sourcedValue); ok {
+		got = v.value
+		c = c.WithComment("got value defined at %s", v.source)
+	}
+	if comment, err := runCheck(c.withCmpOptions(checker), got, args); err != nil {
+		c.fail(fail, c.report(err, comment))
 		return false
 	}
+	return true
+}
+
+// runCheck runs the given checker with the given got value and arguments,
+// and returns the comment extracted from the arguments, if any, along with
+// the resulting error.
+func runCheck(checker Checker, got interface{}, args []interface{}) (Comment, error) {
+	// Ensure that we have a checker.
+	if checkerIsNil(checker) {
+		return Comment{}, BadCheckf("cannot run test: nil checker provided")
+	}
 	// Extract a comment if it has been provided.
 	wantNumArgs := checker.NumArgs()
-	var c Comment
+	var comment Comment
 	if len(args) > 0 {
-		if comment, ok := args[len(args)-1].(Comment); ok {
-			c = comment
+		if cmt, ok := args[len(args)-1].(Comment); ok {
+			comment = cmt
 			args = args[:len(args)-1]
 		}
 	}
+	// Extract compare options if the checker supports them.
+	checker, args = withCallOptions(checker, args)
 	// Validate that we have the correct number of arguments.
 	if len(args) < wantNumArgs {
-		err := BadCheckf("not enough arguments provided to checker: got %d, want %d", len(args), wantNumArgs)
-		fail(report(err, c))
-		return false
+		return comment, BadCheckf("not enough arguments provided to checker: got %d, want %d", len(args), wantNumArgs)
 	}
-	if len(args) > wantNumArgs {
+	if len(args) > wantNumArgs && !isVariadic(checker) {
 		unexpected := make([]string, len(args)-wantNumArgs)
 		for i, a := range args[wantNumArgs:] {
 			unexpected[i] = fmt.Sprintf("%v", a)
 		}
-		err := BadCheckf(
+		return comment, BadCheckf(
 			"too many arguments provided to checker: got %d, want %d: unexpected %s",
 			len(args), wantNumArgs, strings.Join(unexpected, ", "))
-		fail(report(err, c))
-		return false
 	}
-	// Execute the check and report the failure if necessary.
-	if err := checker.Check(got, args); err != nil {
-		fail(report(err, c))
-		return false
+	// Execute the check.
+	return comment, checker.Check(got, args)
+}
+
+// fail records a failure in the stats, calls the registered failure
+// functions and reports the failure using the provided fail function.
+func (c *C) fail(fail func(...interface{}), msg string) {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
 	}
-	return true
+	c.stats.addFailure()
+	for _, f := range c.onFailure {
+		f(msg)
+	}
+	if c.output != nil {
+		c.output.Write([]byte(msg))
+	}
+	fail(msg)
+}
+
+// withCmpOptions returns a checker also using the compare options registered
+// with AddCmpOptions, or the given checker if there are no options or the
+// checker does not support them.
+func (c *C) withCmpOptions(checker Checker) Checker {
+	if len(c.cmpOptions) == 0 || checkerIsNil(checker) {
+		return checker
+	}
+	if newChecker, ok := applyOptions(checker, c.cmpOptions); ok {
+		return newChecker
+	}
+	return checker
+}
+
+// checkerIsNil reports whether the given checker is nil, including typed nil
+// pointers and negations or transformations of nil checkers, which would panic
+// when used.
+func checkerIsNil(checker Checker) bool {
+	if checker == nil {
+		return true
+	}
+	if n, ok := checker.(*notChecker); ok && n != nil {
+		return checkerIsNil(n.Checker)
+	}
+	if v, ok := checker.(*viaChecker); ok && v != nil {
+		return checkerIsNil(v.Checker)
+	}
+	v := reflect.ValueOf(checker)
+	return v.Kind() == reflect.Ptr && v.IsNil()
 }
 
 type runner interface {
 	Run(string, func(*testing.T)) bool
 }
+
+// run runs f as a subtest of t called name, panicking if t does not support
+// subtests.
+func run(t testing.TB, name string, f func(t *testing.T)) bool {
+	r, ok := t.(runner)
+	if !ok {
+		panic(fmt.Sprintf("cannot execute Run with underlying concrete type %T", t))
+	}
+	return r.Run(name, f)
+}
+
+// collector is a testing.TB collecting check failures instead of reporting
+// them.
+type collector struct {
+	testing.TB
+	failures []string
+}
+
+// Error implements testing.TB.Error by collecting the failure.
+func (c *collector) Error(args ...interface{}) {
+	c.failures = append(c.failures, fmt.Sprint(args...))
+}
+
+// Run implements runner.Run by running f as a subtest of the underlying TB.
+func (c *collector) Run(name string, f func(t *testing.T)) bool {
+	return run(c.TB, name, f)
+}
+
+// Fatal implements testing.TB.Fatal by collecting the failure without
+// stopping execution.
+func (c *collector) Fatal(args ...interface{}) {
+	c.failures = append(c.failures, fmt.Sprint(args...))
+}
+
+// groupCollector is a testing.TB collecting assertion failures instead of
+// stopping execution, and reporting check failures as usual.
+type groupCollector struct {
+	testing.TB
+	failed   bool
+	failures []string
+}
+
+// Error implements testing.TB.Error by reporting the failure.
+func (g *groupCollector) Error(args ...interface{}) {
+	if h, ok := g.TB.(helper); ok {
+		h.Helper()
+	}
+	g.failed = true
+	g.TB.Error(args...)
+}
+
+// Run implements runner.Run by running f as a subtest of the underlying TB.
+func (g *groupCollector) Run(name string, f func(t *testing.T)) bool {
+	return run(g.TB, name, f)
+}
+
+// Fatal implements testing.TB.Fatal by collecting the failure without
+// stopping execution.
+func (g *groupCollector) Fatal(args ...interface{}) {
+	g.failures = append(g.failures, fmt.Sprint(args...))
+}
+
+// syncWriter is an io.Writer serializing writes to the underlying writer.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write implements io.Writer.Write.
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// helper is implemented by testing.TB values supporting test helpers, so that
+// failures are reported at the line of the Check or Assert call.
+type helper interface {
+	Helper()
+}
@@ -5,8 +5,13 @@ package quicktest_test
 import (
 	"bytes"
 	"fmt"
+	"runtime"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	qt "github.com/frankban/quicktest"
 )
@@ -52,9 +57,30 @@ var cTests = []struct {
 	got:             47,
 	args:            []interface{}{qt.Commentf("")},
 	expectedFailure: "47 is not nil\n",
+}, {
+	about:   "compare options with comment",
+	checker: qt.DeepEquals,
+	got:     []int{1, 2},
+	args:    []interface{}{[]int{2, 1}, cmpopts.SortSlices(func(a, b int) bool { return a < b }), qt.Commentf("sorted")},
 }, {
 	about:           "nil checker",
 	expectedFailure: "cannot run test: nil checker provided",
+}, {
+	about:           "typed nil checker",
+	checker:         (*nilChecker)(nil),
+	expectedFailure: "cannot run test: nil checker provided",
+}, {
+	about:           "negated nil checker",
+	checker:         qt.Not(nil),
+	got:             42,
+	args:            []interface{}{42},
+	expectedFailure: "cannot run test: nil checker provided",
+}, {
+	about:           "transformed nil checker",
+	checker:         qt.Via(strings.ToUpper, nil),
+	got:             "bad wolf",
+	args:            []interface{}{"BAD WOLF"},
+	expectedFailure: "cannot run test: nil checker provided",
 }, {
 	about:           "not enough arguments",
 	checker:         qt.Equals,
@@ -85,8 +111,45 @@ var cTests = []struct {
 	got:             42,
 	args:            []interface{}{nil, qt.Commentf("these are the voyages")},
 	expectedFailure: "these are the voyages\ntoo many arguments provided to checker: got 1, want 0: unexpected <nil>",
+}, {
+	about:   "variadic checker with minimum arguments",
+	checker: sumAllChecker{},
+	got:     2,
+	args:    []interface{}{2},
+}, {
+	about:   "variadic checker with more arguments",
+	checker: sumAllChecker{},
+	got:     6,
+	args:    []interface{}{1, 2, 3},
+}, {
+	about:           "variadic checker failure with comment",
+	checker:         sumAllChecker{},
+	got:             7,
+	args:            []interface{}{1, 2, 3, qt.Commentf("these are the voyages")},
+	expectedFailure: "these are the voyages\n7 is not the sum of [1 2 3]\n",
+}, {
+	about:           "variadic checker with not enough arguments",
+	checker:         sumAllChecker{},
+	got:             0,
+	expectedFailure: "not enough arguments provided to checker: got 0, want 1",
+}, {
+	about:   "negated variadic checker",
+	checker: qt.Not(sumAllChecker{}),
+	got:     7,
+	args:    []interface{}{1, 2, 3},
+}, {
+	about:           "negated variadic checker failure",
+	checker:         qt.Not(sumAllChecker{}),
+	got:             6,
+	args:            []interface{}{1, 2, 3},
+	expectedFailure: "6 is the sum of [1 2 3], but should not\n",
 }}
 
+// nilChecker is a checker used to check that typed nil checkers are detected.
+type nilChecker struct {
+	qt.Checker
+}
+
 func TestCAssertCheck(t *testing.T) {
 	for _, test := range cTests {
 		t.Run("Check: "+test.about, func(t *testing.T) {
@@ -149,7 +212,592 @@ func TestCRunPanic(t *testing.T) {
 	assertBool(t, run, true)
 }
 
+func TestCBenchmark(t *testing.T) {
+	var checked, stopped bool
+	result := testing.Benchmark(func(b *testing.B) {
+		c := qt.New(b)
+		checked = c.Check(42, qt.Equals, 42)
+		stopped = true
+		c.Assert(42, qt.Equals, 47)
+		stopped = false
+	})
+	assertBool(t, checked, true)
+	assertBool(t, stopped, true)
+	if result.N != 0 {
+		t.Fatalf("failed benchmark result: got N=%d, want 0", result.N)
+	}
+}
+
+var cfTests = []struct {
+	about           string
+	checker         qt.Checker
+	got             interface{}
+	args            []interface{}
+	expectedFailure string
+}{{
+	about:   "success",
+	checker: qt.Equals,
+	got:     42,
+	args:    []interface{}{42, "answer %d", 42},
+}, {
+	about:   "success without comment",
+	checker: qt.IsNil,
+	got:     nil,
+}, {
+	about:           "failure with comment",
+	checker:         qt.Equals,
+	got:             42,
+	args:            []interface{}{47, "the answer is %d, not %d", 42, 47},
+	expectedFailure: "the answer is 42, not 47\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 47\n",
+}, {
+	about:           "failure with constant comment",
+	checker:         qt.IsNil,
+	got:             42,
+	args:            []interface{}{"these are the voyages"},
+	expectedFailure: "these are the voyages\n42 is not nil\n",
+}, {
+	about:           "failure without comment",
+	checker:         qt.IsNil,
+	got:             42,
+	expectedFailure: "42 is not nil\n",
+}, {
+	about:           "failure with compare options and comment",
+	checker:         qt.DeepEquals,
+	got:             []string{"bad", "wolf"},
+	args:            []interface{}{[]string{"wolf"}, cmpopts.SortSlices(func(a, b string) bool { return a < b }), "sorted %s", "strings"},
+	expectedFailure: "sorted strings\nvalues are not equal (1 difference found):\n(-got +want)\n",
+}, {
+	about:   "success with compare options",
+	checker: qt.DeepEquals,
+	got:     []string{"bad", "wolf"},
+	args:    []interface{}{[]string{"wolf", "bad"}, cmpopts.SortSlices(func(a, b string) bool { return a < b }), "sorted"},
+}, {
+	about:           "format not a string",
+	checker:         qt.Equals,
+	got:             42,
+	args:            []interface{}{42, 47},
+	expectedFailure: "comment format must be a string, got int instead\n",
+}, {
+	about:           "not enough arguments",
+	checker:         qt.Equals,
+	got:             42,
+	expectedFailure: "not enough arguments provided to checker: got 0, want 1\n",
+}, {
+	about:           "nil checker",
+	args:            []interface{}{"bad wolf"},
+	expectedFailure: "cannot run test: nil checker provided",
+}, {
+	about:   "variadic checker",
+	checker: sumAllChecker{},
+	got:     42,
+	args:    []interface{}{42},
+}, {
+	about:           "variadic checker with additional arguments",
+	checker:         sumAllChecker{},
+	got:             42,
+	args:            []interface{}{40, 2},
+	expectedFailure: "cannot use a comment format with variadic checker quicktest_test.sumAllChecker: use Commentf instead\n",
+}, {
+	about:           "variadic checker with comment format",
+	checker:         sumAllChecker{},
+	got:             42,
+	args:            []interface{}{42, "answer %d", 42},
+	expectedFailure: "cannot use a comment format with variadic checker quicktest_test.sumAllChecker: use Commentf instead\n",
+}}
+
+func TestCAssertfCheckf(t *testing.T) {
+	for _, test := range cfTests {
+		t.Run("Checkf: "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Checkf(test.got, test.checker, test.args...)
+			checkResult(t, ok, tt.errorString(), test.expectedFailure)
+			if tt.fatalString() != "" {
+				t.Fatalf("no fatal messages expected, but got %q", tt.fatalString())
+			}
+		})
+		t.Run("Assertf: "+test.about, func(t *testing.T) {
+			tt := &testingT{}
+			c := qt.New(tt)
+			ok := c.Assertf(test.got, test.checker, test.args...)
+			checkResult(t, ok, tt.fatalString(), test.expectedFailure)
+			if tt.errorString() != "" {
+				t.Fatalf("no error messages expected, but got %q", tt.errorString())
+			}
+		})
+	}
+}
+
+func TestResults(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check(qt.Results(strconv.Atoi("42")), qt.DeepEquals, []interface{}{42, nil})
+	checkResult(t, ok, tt.errorString(), "")
+	ok = c.Check(qt.Results(strconv.Atoi("bad wolf")), qt.HasLen, 2)
+	checkResult(t, ok, tt.errorString(), "")
+	ok = c.Check(qt.Results(), qt.HasLen, 0)
+	checkResult(t, ok, tt.errorString(), "")
+}
+
+func TestCSilentFailure(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check(42, silentChecker{}, qt.Commentf("bad wolf"))
+	checkResult(t, ok, tt.errorString(), "bad wolf\nquicktest_test.go:")
+	if strings.Contains(tt.errorString(), "\nsilent failure\n") {
+		t.Fatalf("silent failure message included in output:\n%s", tt.errorString())
+	}
+}
+
+// silentChecker is a checker always failing silently.
+type silentChecker struct{}
+
+func (silentChecker) Check(got interface{}, args []interface{}) error {
+	return qt.SilentFailuref("silent failure")
+}
+
+func (silentChecker) Negate(got interface{}, args []interface{}) error {
+	return qt.SilentFailuref("silent failure")
+}
+
+func (silentChecker) NumArgs() int {
+	return 0
+}
+
+func TestCNotArgs(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check(5, qt.Not(sumChecker{}), 2, 3)
+	checkResult(t, ok, tt.errorString(), "5 is the sum of a=2 and b=3, but should not\n")
+
+	tt = &testingT{}
+	c = qt.New(tt)
+	ok = c.Check(5, qt.Not(sumChecker{}), 2)
+	checkResult(t, ok, tt.errorString(), "not enough arguments provided to checker: got 1, want 2\n")
+
+	tt = &testingT{}
+	c = qt.New(tt)
+	ok = c.Check(6, qt.Not(sumChecker{}), 2, 3)
+	checkResult(t, ok, tt.errorString(), "")
+}
+
+// sumChecker is a two arguments checker succeeding when the obtained value is
+// the sum of its arguments.
+type sumChecker struct{}
+
+func (sumChecker) Check(got interface{}, args []interface{}) error {
+	a, b := args[0].(int), args[1].(int)
+	if got != a+b {
+		return fmt.Errorf("%v is not the sum of a=%d and b=%d", got, a, b)
+	}
+	return nil
+}
+
+func (sumChecker) Negate(got interface{}, args []interface{}) error {
+	a, b := args[0].(int), args[1].(int)
+	if got == a+b {
+		return fmt.Errorf("%v is the sum of a=%d and b=%d, but should not", got, a, b)
+	}
+	return nil
+}
+
+func (sumChecker) NumArgs() int {
+	return 2
+}
+
+// sumAllChecker is a variadic checker succeeding when the obtained value is
+// the sum of its arguments.
+type sumAllChecker struct{}
+
+func (sumAllChecker) Check(got interface{}, args []interface{}) error {
+	if got != sum(args) {
+		return fmt.Errorf("%v is not the sum of %v", got, args)
+	}
+	return nil
+}
+
+func (sumAllChecker) Negate(got interface{}, args []interface{}) error {
+	if got == sum(args) {
+		return fmt.Errorf("%v is the sum of %v, but should not", got, args)
+	}
+	return nil
+}
+
+func (sumAllChecker) NumArgs() int {
+	return 1
+}
+
+func (sumAllChecker) Variadic() bool {
+	return true
+}
+
+// sum returns the sum of the given ints.
+func sum(values []interface{}) int {
+	var n int
+	for _, v := range values {
+		n += v.(int)
+	}
+	return n
+}
+
+func TestCHelper(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.Check(42, qt.Equals, 42)
+	if tt.helperCalls == 0 {
+		t.Fatal("Check: Helper not called")
+	}
+	tt.helperCalls = 0
+	c.Assert(42, qt.Equals, 47)
+	if tt.helperCalls == 0 {
+		t.Fatal("Assert: Helper not called")
+	}
+}
+
+func TestCWithComment(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c1 := c.WithComment("case %d", 1)
+	ok := c1.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "case 1\nnot equal:\n")
+
+	tt = &testingT{}
+	c = qt.New(tt)
+	c2 := c.WithComment("group").WithComment("case %d", 2)
+	ok = c2.Check(42, qt.Equals, 47, qt.Commentf("answer"))
+	checkResult(t, ok, tt.errorString(), "group\ncase 2\nanswer\nnot equal:\n")
+
+	// The original checker is not affected.
+	tt = &testingT{}
+	c = qt.New(tt)
+	c.WithComment("case %d", 3)
+	ok = c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n")
+}
+
+func TestCWithCommentIndependent(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt).WithComment("group")
+	c1 := c.WithComment("case 1")
+	c2 := c.WithComment("case 2")
+	c1.Check(42, qt.Equals, 47)
+	c2.Check(42, qt.Equals, 47)
+	got := tt.errorString()
+	if !strings.Contains(got, "\ngroup\ncase 1\nnot equal:\n") || !strings.Contains(got, "\ngroup\ncase 2\nnot equal:\n") {
+		t.Fatalf("unexpected output:\n%s", got)
+	}
+	if c1.TB != tt || c2.TB != tt {
+		t.Fatal("underlying testing object not shared")
+	}
+}
+
+func TestCWithSource(t *testing.T) {
+	got, line := qt.WithSource(42), callerLine()
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.Check(got, qt.Equals, 42)
+	checkResult(t, ok, tt.errorString(), "")
+
+	ok = c.Check(got, qt.Equals, 47, qt.Commentf("answer"))
+	checkResult(t, ok, tt.errorString(), fmt.Sprintf("got value defined at quicktest_test.go:%d\nanswer\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 47\n", line))
+
+	tt = &testingT{}
+	c = qt.New(tt)
+	ok = c.Assert(got, qt.Not(qt.Equals), 42)
+	checkResult(t, ok, tt.fatalString(), fmt.Sprintf("got value defined at quicktest_test.go:%d\nboth values equal 42, but should not\n", line))
+}
+
+// callerLine returns the line from which it is called.
+func callerLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestCOnFailure(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	var calls []string
+	c.OnFailure(func(report string) {
+		if tt.errorString() != "" {
+			t.Fatal("failure reported before calling the failure function")
+		}
+		calls = append(calls, "first: "+report)
+	})
+	c.OnFailure(func(report string) {
+		calls = append(calls, "second: "+report)
+	})
+	c.Check(42, qt.Equals, 42)
+	if len(calls) != 0 {
+		t.Fatalf("failure functions called on success: %q", calls)
+	}
+	ok := c.Check(42, qt.Equals, 47)
+	report := tt.errorString()
+	checkResult(t, ok, report, "not equal:\n")
+	if len(calls) != 2 || calls[0] != "first: "+report || calls[1] != "second: "+report {
+		t.Fatalf("unexpected failure function calls: %q", calls)
+	}
+}
+
+func TestCOnFailureIndependent(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	var calls []string
+	c.OnFailure(func(string) {
+		calls = append(calls, "parent")
+	})
+	c1 := c.WithComment("child")
+	c1.OnFailure(func(string) {
+		calls = append(calls, "child")
+	})
+	c.Check(42, qt.Equals, 47)
+	c1.Check(42, qt.Equals, 47)
+	if strings.Join(calls, " ") != "parent parent child" {
+		t.Fatalf("unexpected failure function calls: %q", calls)
+	}
+}
+
+func TestCSetOutput(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	var buf bytes.Buffer
+	c.SetOutput(&buf)
+	c.Check(42, qt.Equals, 42)
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected output on success: %q", buf.String())
+	}
+	ok := c.Check(42, qt.Equals, 47)
+	checkResult(t, ok, tt.errorString(), "not equal:\n")
+	c.WithComment("comment").Assert(42, qt.IsNil)
+	if want := tt.errorString() + tt.fatalString(); buf.String() != want {
+		t.Fatalf("unexpected output:\ngot  %q\nwant %q", buf.String(), want)
+	}
+
+	// Reports are no longer written after resetting the output.
+	buf.Reset()
+	c.SetOutput(nil)
+	c.Check(42, qt.Equals, 47)
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected output after reset: %q", buf.String())
+	}
+}
+
+func TestCCheckAll(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	var run bool
+	ok := c.CheckAll(func(c *qt.C) {
+		c.Assert(42, qt.Equals, 47)
+		c.Check("these are the voyages", qt.Equals, "these are the voyages")
+		c.Assert(nil, qt.Not(qt.IsNil))
+		run = true
+	})
+	assertBool(t, ok, false)
+	assertBool(t, run, true)
+	assertPrefix(t, tt.errorString(), "\n2 check(s) failed:\n\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 47\n")
+	if !strings.Contains(tt.errorString(), "\nthe value is nil, but should not\n") {
+		t.Fatalf("missing failure in output:\n%s", tt.errorString())
+	}
+	if tt.fatalString() != "" {
+		t.Fatalf("no fatal messages expected, but got %q", tt.fatalString())
+	}
+}
+
+func TestCCheckAllSuccess(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.CheckAll(func(c *qt.C) {
+		c.Assert(42, qt.Equals, 42)
+		c.Check(nil, qt.IsNil)
+	})
+	checkResult(t, ok, tt.errorString(), "")
+}
+
+func TestCCheckAllRun(t *testing.T) {
+	tt := &testingT{subTestResult: true}
+	c := qt.New(tt)
+	var run bool
+	ok := c.CheckAll(func(c *qt.C) {
+		c.Run("subtest", func(innerC *qt.C) {
+			run = true
+			if innerC.TB != tt.subTestT {
+				t.Fatalf("subtest testing object: got %p, want %p", innerC.TB, tt.subTestT)
+			}
+		})
+	})
+	assertBool(t, run, true)
+	checkResult(t, ok, tt.errorString(), "")
+}
+
+func TestCCheckAllRunPanic(t *testing.T) {
+	c := qt.New(&testing.B{})
+	defer func() {
+		r := recover()
+		if r != "cannot execute Run with underlying concrete type *testing.B" {
+			t.Fatalf("unexpected panic recover: %v", r)
+		}
+	}()
+	c.CheckAll(func(c *qt.C) {
+		c.Run("panic", func(innerC *qt.C) {})
+	})
+}
+
+func TestCAssertAll(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.AssertAll(func(c *qt.C) {
+		c.Assert(42, qt.Equals, 47)
+		c.Check(42, qt.IsNil)
+	})
+	checkResult(t, ok, tt.fatalString(), "2 check(s) failed:\n\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 47\n")
+	if tt.errorString() != "" {
+		t.Fatalf("no error messages expected, but got %q", tt.errorString())
+	}
+}
+
+func TestCAssertGroup(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	var run bool
+	ok := c.AssertGroup(func(c *qt.C) {
+		c.Assert(42, qt.Equals, 47)
+		c.Check(42, qt.IsNil)
+		c.Assert(nil, qt.Not(qt.IsNil))
+		run = true
+	})
+	assertBool(t, ok, false)
+	assertBool(t, run, true)
+	assertPrefix(t, tt.fatalString(), "\n2 assertion(s) failed:\n\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 47\n")
+	if !strings.Contains(tt.fatalString(), "\nthe value is nil, but should not\n") {
+		t.Fatalf("missing failure in output:\n%s", tt.fatalString())
+	}
+	assertPrefix(t, tt.errorString(), "\n42 is not nil\n")
+	if strings.Contains(tt.errorString(), "not equal") {
+		t.Fatalf("unexpected assertion failure in error output:\n%s", tt.errorString())
+	}
+}
+
+func TestCAssertGroupCheckFailure(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.AssertGroup(func(c *qt.C) {
+		c.Assert(42, qt.Equals, 42)
+		c.Check(42, qt.Equals, 47)
+	})
+	checkResult(t, ok, tt.errorString(), "not equal:\n(-got +want)\n\t-: 42\n\t+: 47\n")
+	if tt.fatalString() != "" {
+		t.Fatalf("no fatal messages expected, but got %q", tt.fatalString())
+	}
+}
+
+func TestCAssertGroupSuccess(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	ok := c.AssertGroup(func(c *qt.C) {
+		c.Assert(42, qt.Equals, 42)
+		c.Check(nil, qt.IsNil)
+	})
+	checkResult(t, ok, tt.fatalString(), "")
+	if tt.errorString() != "" {
+		t.Fatalf("no error messages expected, but got %q", tt.errorString())
+	}
+}
+
+func TestCAssertGroupPanic(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	func() {
+		defer func() {
+			if r := recover(); r != "bad wolf" {
+				t.Fatalf("unexpected panic recover: %v", r)
+			}
+		}()
+		c.AssertGroup(func(c *qt.C) {
+			c.Assert(42, qt.Equals, 47)
+			panic("bad wolf")
+		})
+	}()
+	assertPrefix(t, tt.errorString(), "\n1 assertion(s) failed:\n\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 47\n")
+	if tt.fatalString() != "" {
+		t.Fatalf("no fatal messages expected, but got %q", tt.fatalString())
+	}
+}
+
+func TestCAssertGroupRun(t *testing.T) {
+	tt := &testingT{subTestResult: true}
+	c := qt.New(tt)
+	var run bool
+	ok := c.AssertGroup(func(c *qt.C) {
+		c.Run("subtest", func(innerC *qt.C) {
+			run = true
+			if innerC.TB != tt.subTestT {
+				t.Fatalf("subtest testing object: got %p, want %p", innerC.TB, tt.subTestT)
+			}
+		})
+	})
+	assertBool(t, run, true)
+	checkResult(t, ok, tt.fatalString(), "")
+}
+
+func TestCAddCmpOptions(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.AddCmpOptions(cmpopts.SortSlices(func(a, b int) bool { return a < b }))
+	ok := c.Check([]int{1, 2, 3}, qt.DeepEquals, []int{3, 2, 1})
+	checkResult(t, ok, tt.errorString(), "")
+	ok = c.Check([]int{1, 2, 3}, qt.CmpEquals(), []int{3, 1, 2})
+	checkResult(t, ok, tt.errorString(), "")
+
+	// Negated checkers also use the options.
+	ok = c.Check([]int{1, 2}, qt.Not(qt.DeepEquals), []int{2, 1})
+	checkResult(t, ok, tt.errorString(), "both values deeply equal []int{1, 2}, but should not\n")
+
+	// Options provided to the check are used in addition.
+	tt = &testingT{}
+	c = qt.New(tt)
+	c.AddCmpOptions(cmpopts.SortSlices(func(a, b int) bool { return a < b }))
+	ok = c.Check([]int(nil), qt.DeepEquals, []int{}, cmpopts.EquateEmpty())
+	checkResult(t, ok, tt.errorString(), "")
+	ok = c.Check([]int{2, 1}, qt.DeepEquals, []int{1, 2}, cmpopts.EquateEmpty())
+	checkResult(t, ok, tt.errorString(), "")
+
+	// Checkers wrapping a checker supporting compare options also use them.
+	ok = c.Check([]int{2, 1}, qt.Via(func(ints []int) []int { return ints }, qt.DeepEquals), []int{1, 2})
+	checkResult(t, ok, tt.errorString(), "")
+	ok = c.Check([]int{2, 1}, qt.WithTimeout(time.Minute, qt.DeepEquals), []int{1, 2})
+	checkResult(t, ok, tt.errorString(), "")
+}
+
+func TestCAddCmpOptionsScope(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	child := c.WithComment("child")
+	c.AddCmpOptions(cmpopts.EquateEmpty())
+	derived := c.WithComment("derived")
+	derived.AddCmpOptions(cmpopts.SortSlices(func(a, b int) bool { return a < b }))
+
+	// Options registered on a checker do not affect other checkers.
+	ok := qt.New(tt).Check([]int{}, qt.DeepEquals, []int(nil))
+	assertBool(t, ok, false)
+	ok = child.Check([]int{}, qt.DeepEquals, []int(nil))
+	assertBool(t, ok, false)
+	ok = c.Check([]int{1, 2}, qt.DeepEquals, []int{2, 1})
+	assertBool(t, ok, false)
+
+	// Derived checkers inherit the options.
+	ok = derived.Check([]int{}, qt.DeepEquals, []int(nil))
+	assertBool(t, ok, true)
+	ok = derived.Check([]int{1, 2}, qt.DeepEquals, []int{2, 1})
+	assertBool(t, ok, true)
+	ok = c.Check([]int{}, qt.DeepEquals, []int(nil))
+	assertBool(t, ok, true)
+}
+
 func checkResult(t *testing.T, ok bool, got, want string) {
+	// go-cmp randomly uses non-breaking spaces in its diffs, to discourage
+	// relying on their exact output: normalize them so that the expected
+	// diffs can be written literally.
+	got = strings.Replace(got, "\u00a0", " ", -1)
+	want = strings.Replace(want, "\u00a0", " ", -1)
 	if want != "" {
 		assertPrefix(t, got, "\n"+want)
 		assertBool(t, ok, false)
@@ -171,6 +819,13 @@ type testingT struct {
 	subTestResult bool
 	subTestName   string
 	subTestT      *testing.T
+
+	helperCalls int
+}
+
+// Helper overrides *testing.T.Helper so that calls are counted.
+func (t *testingT) Helper() {
+	t.helperCalls++
 }
 
 // Error overrides *testing.T.Error so that messages are collected.
@@ -6,31 +6,167 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
+	"strings"
 	"text/tabwriter"
 )
 
+// RenderFailure runs the given checker with the provided got value and
+// arguments, and returns the resulting failure report, or an empty string if
+// the check succeeds. As with Check and Assert, a Comment can be provided as
+// the last argument. The report is the one included in the test output by
+// a failing check, except for the source code context, which is omitted so
+// that the result does not depend on the caller. This function is useful for
+// testing the failure messages of custom checkers.
+// For instance:
+//
+//     report := qt.RenderFailure(myChecker, got, want, qt.Commentf("bad wolf"))
+//
+func RenderFailure(checker Checker, got interface{}, args ...interface{}) string {
+	cmt, err := runCheck(checker, got, args)
+	if err == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	New(nil).writeReport(&buf, err, cmt)
+	return buf.String()
+}
+
 // report generates a failure report for the given error, optionally including
 // the in the output the given comment
-func report(err error, c Comment) string {
+func (c *C) report(err error, cmt Comment) string {
+	file, line, ok := invocation()
+	return c.reportAt(err, cmt, file, line, ok)
+}
+
+// reportAt is like report, but the source code context included in the report
+// is the one at the given file and line, if ok is true.
+func (c *C) reportAt(err error, cmt Comment, file string, line int, ok bool) string {
 	var buf bytes.Buffer
 	buf.WriteString("\n")
-	if comment := c.String(); comment != "" {
-		fmt.Fprintln(&buf, comment)
-	}
-	fmt.Fprintln(&buf, err.Error())
-	writeInvocation(&buf)
+	c.writeReport(&buf, err, cmt)
+	writeInvocation(&buf, file, line, ok, c.contextLines)
 	return buf.String()
 }
 
-// writeInvocation writes the source code context for the current failure into
-// the provided writer.
-func writeInvocation(w io.Writer) {
-	// TODO: we can do better than 4.
-	_, file, line, ok := runtime.Caller(4)
+// writeReport writes the comments and the failure message for the given
+// error into the provided writer.
+func (c *C) writeReport(w io.Writer, err error, cmt Comment) {
+	for _, comment := range c.comments {
+		writeComment(w, comment)
+	}
+	writeComment(w, cmt)
+	if !IsSilentFailure(err) {
+		msg := err.Error()
+		if fe, ok := err.(formattedError); ok && (c.format != nil || c.showTypes) {
+			msg = fe.errorWith(valueFormatter{
+				format:    c.format,
+				showTypes: c.showTypes,
+			})
+		}
+		msg = truncateLines(truncateLineLengths(msg, c.maxValueLen), c.maxReportLines)
+		if c.color {
+			msg = colorize(msg)
+		}
+		fmt.Fprintln(w, msg)
+	}
+}
+
+// writeComment writes the given comment into the provided writer, unless
+// the comment is empty.
+func writeComment(w io.Writer, cmt Comment) {
+	if comment := cmt.String(); comment != "" {
+		fmt.Fprintln(w, comment)
+	}
+}
+
+// truncateLines returns s truncated to its first max lines, followed by a
+// line reporting how many lines have been omitted. If max is zero, s is
+// returned unchanged.
+func truncateLines(s string, max int) string {
+	if max == 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) <= max {
+		return s
+	}
+	return fmt.Sprintf("%s\n... (%d more lines)", strings.Join(lines[:max], "\n"), len(lines)-max)
+}
+
+// truncateLineLengths returns s with each value, that is each indented line,
+// longer than max characters, excluding its indentation, truncated to its
+// first max characters, followed by the number of omitted characters. If max
+// is zero, s is returned unchanged.
+func truncateLineLengths(s string, max int) string {
+	if max == 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "\t") {
+			// Only indented lines include values: section headers are kept.
+			continue
+		}
+		content := strings.TrimLeft(line, " \t")
+		runes := []rune(content)
+		if len(runes) <= max {
+			continue
+		}
+		indent := line[:len(line)-len(content)]
+		lines[i] = fmt.Sprintf("%s%s… (truncated, %d more)", indent, string(runes[:max]), len(runes)-max)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// colorize returns s with the removed and added lines of its diff sections
+// colored using ANSI escape sequences, respectively in red and green. Diff
+// sections start with a header like "(-got +want)" or "(diff)", and end at the
+// next section header.
+func colorize(s string) string {
+	lines := strings.Split(s, "\n")
+	inDiff := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "(") {
+			inDiff = line == "(diff)" || diffHeader.MatchString(line)
+			continue
+		}
+		if !inDiff {
+			continue
+		}
+		switch content := strings.TrimLeft(line, " \t"); {
+		case strings.HasPrefix(content, "-"):
+			lines[i] = colorRed + line + colorReset
+		case strings.HasPrefix(content, "+"):
+			lines[i] = colorGreen + line + colorReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffHeader matches the headers of diff sections in failure messages, for
+// instance "(-got +want)".
+var diffHeader = regexp.MustCompile(`^\(-\w+ \+\w+\)$`)
+
+// ANSI escape sequences used to color failure reports.
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorReset = "\x1b[0m"
+)
+
+// writeInvocation writes the source code context for a failure at the given
+// file and line into the provided writer, including the given number of lines
+// before and after the statement.
+func writeInvocation(w io.Writer, file string, line int, ok bool, contextLines int) {
 	if !ok {
 		fmt.Fprintln(w, "<invocation not available>")
 		return
@@ -42,16 +178,22 @@ func writeInvocation(w io.Writer) {
 		return
 	}
 	defer f.Close()
+	// Show the whole statement including the invocation when the source can
+	// be parsed, so that checks spanning multiple lines are fully displayed.
+	first, last := line, line
+	if start, end, ok := statementLines(file, line); ok {
+		first, last = start, end
+	}
 	var current int
 	var found bool
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	sc := bufio.NewScanner(f)
 	for sc.Scan() {
 		current++
-		if current > line+contextLines {
+		if current > last+contextLines {
 			break
 		}
-		if current < line-contextLines {
+		if current < first-contextLines {
 			continue
 		}
 		prefix := fmt.Sprintf("        %d", current)
@@ -71,6 +213,61 @@ func writeInvocation(w io.Writer) {
 	}
 }
 
-// contextLines holds the number of lines of code to show when showing a
-// failure context.
-const contextLines = 3
+// statementLines returns the first and last lines of the innermost simple
+// statement, for instance an expression or an assignment, including the given
+// line in the given Go source file. It reports whether such a statement has
+// been found.
+func statementLines(file string, line int) (start, end int, ok bool) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, 0)
+	if err != nil {
+		return 0, 0, false
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		first, last := fset.Position(n.Pos()).Line, fset.Position(n.End()).Line
+		if line < first || line > last {
+			return false
+		}
+		switch n.(type) {
+		case *ast.ExprStmt, *ast.AssignStmt, *ast.DeclStmt, *ast.ReturnStmt,
+			*ast.GoStmt, *ast.DeferStmt, *ast.IncDecStmt, *ast.SendStmt:
+			// Inner statements, for instance the ones within function
+			// literals, are visited later and take precedence.
+			start, end, ok = first, last, true
+		}
+		return true
+	})
+	return start, end, ok
+}
+
+// defaultMaxReportLines holds the default maximum number of lines of the
+// checker failure message included in reports.
+const defaultMaxReportLines = 1000
+
+// invocation returns the file and line of the outermost call into this
+// package, which is the location of the failed Check or Assert invocation.
+func invocation() (file string, line int, ok bool) {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			return frame.File, frame.Line, frame.File != ""
+		}
+		if !more {
+			return "", 0, false
+		}
+	}
+}
+
+// pkgPrefix holds the prefix of the names of functions defined in this
+// package.
+var pkgPrefix = reflect.TypeOf(C{}).PkgPath() + "."
+
+// defaultContextLines holds the default number of lines of code to show
+// before and after the statement when showing a failure context.
+const defaultContextLines = 3
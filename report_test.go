@@ -3,6 +3,8 @@
 package quicktest_test
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -36,12 +38,210 @@ not equal:
 (-got +want)
         -: 42
         +: 47
-report_test.go:20:
-        17     // Context line #1.
-        18     // Context line #2.
-        19     // Context line #3.
-        20!    c.Assert(42, qt.Equals, 47)
-        21     // Context line #4.
-        22     // Context line #5.
-        23     // Context line #6.
+report_test.go:22:
+        19     // Context line #1.
+        20     // Context line #2.
+        21     // Context line #3.
+        22!    c.Assert(42, qt.Equals, 47)
+        23     // Context line #4.
+        24     // Context line #5.
+        25     // Context line #6.
 `
+
+func TestCodeOutputMultiline(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	// Context line #1.
+	c.Assert(
+		[]int{42, 47},
+		qt.DeepEquals,
+		[]int{42, 47, 0},
+	)
+	// Context line #2.
+	output := strings.Replace(tt.fatalString(), "\t", "        ", -1)
+	if !strings.HasSuffix(output, expectedMultilineCodeOutput) {
+		t.Fatalf(`failure:
+------------------------------ got ------------------------------
+%s------------------------------ want suffix ----------------------
+%s-----------------------------------------------------------------`,
+			output, expectedMultilineCodeOutput)
+	}
+}
+
+var expectedMultilineCodeOutput = `
+        52     tt := &testingT{}
+        53     c := qt.New(tt)
+        54     // Context line #1.
+        55!    c.Assert(
+        56       []int{42, 47},
+        57       qt.DeepEquals,
+        58       []int{42, 47, 0},
+        59     )
+        60     // Context line #2.
+        61     output := strings.Replace(tt.fatalString(), "\t", "        ", -1)
+        62     if !strings.HasSuffix(output, expectedMultilineCodeOutput) {
+`
+
+func TestReportTruncation(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.SetMaxReportLines(2)
+	c.Check(42, qt.Equals, 47)
+	assertPrefix(t, tt.errorString(), "\nnot equal:\n(-got +want)\n... (2 more lines)\nreport_test.go:")
+}
+
+func TestReportShowTypes(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.SetShowTypes(true)
+	c.Check(42, qt.Equals, int64(42))
+	assertPrefix(t, tt.errorString(), "\nnot equal:\n(-got +want)\n\t-: 42 (int)\n\t+: 42 (int64)\nreport_test.go:")
+}
+
+func TestReportShowTypesDisabled(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.Check(42, qt.Equals, int64(42))
+	assertPrefix(t, tt.errorString(), "\nnot equal:\n(-got +want)\n\t-: 42\n\t+: 42\nreport_test.go:")
+}
+
+func TestReportDefaultTruncation(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.Check(2000, linesChecker{})
+	assertPrefix(t, tt.errorString(), "\nline 0\nline 1\n")
+	if !strings.Contains(tt.errorString(), "\nline 999\n... (1000 more lines)\n") {
+		t.Fatalf("report not truncated:\n%s", tt.errorString())
+	}
+}
+
+func TestReportNoTruncation(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.SetMaxReportLines(0)
+	c.Check(2000, linesChecker{})
+	if !strings.Contains(tt.errorString(), "\nline 1999\n") {
+		t.Fatalf("report truncated:\n%s", tt.errorString())
+	}
+}
+
+func TestReportValueTruncation(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.SetMaxValueLen(20)
+	c.Check(strings.Repeat("a", 42), qt.Equals, "bad wolf")
+	assertPrefix(t, tt.errorString(), "\nnot equal:\n(-got +want)\n\t-: \"aaaaaaaaaaaaaaaa… (truncated, 27 more)\n\t+: \"bad wolf\"\nreport_test.go:")
+}
+
+func TestReportValueTruncationMultibyte(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.SetMaxValueLen(6)
+	c.Check("ẞad wolf", qt.Equals, "b")
+	assertPrefix(t, tt.errorString(), "\nnot equal:\n(-got +want)\n\t-: \"ẞa… (truncated, 7 more)\n\t+: \"b\"\nreport_test.go:")
+}
+
+func TestReportNoValueTruncation(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.Check(strings.Repeat("a", 4242), qt.Equals, "bad wolf")
+	if !strings.Contains(tt.errorString(), "\t-: \""+strings.Repeat("a", 4242)+"\"\n") {
+		t.Fatalf("value truncated:\n%s", tt.errorString())
+	}
+}
+
+func TestReportContextLines(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithContextLines(1))
+	// Context line #1.
+	// Context line #2.
+	c.Assert(42, qt.Equals, 47)
+	line := callerLine() - 1
+	output := strings.Replace(tt.fatalString(), "\t", "        ", -1)
+	want := fmt.Sprintf(`
+report_test.go:%d:
+        %d     // Context line #2.
+        %d!    c.Assert(42, qt.Equals, 47)
+        %d     line := callerLine() - 1
+`, line, line-1, line, line+1)
+	if !strings.HasSuffix(output, want) {
+		t.Fatalf("unexpected output:\n%s\nwant suffix:\n%s", output, want)
+	}
+}
+
+func TestReportNoContextLines(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt, qt.WithContextLines(0))
+	c.Assert(42, qt.Equals, 47)
+	line := callerLine() - 1
+	output := strings.Replace(tt.fatalString(), "\t", "        ", -1)
+	want := fmt.Sprintf("\nreport_test.go:%d:\n        %d!    c.Assert(42, qt.Equals, 47)\n", line, line)
+	if !strings.HasSuffix(output, want) {
+		t.Fatalf("unexpected output:\n%s\nwant suffix:\n%s", output, want)
+	}
+}
+
+var renderFailureTests = []struct {
+	about    string
+	checker  qt.Checker
+	got      interface{}
+	args     []interface{}
+	expected string
+}{{
+	about:   "success",
+	checker: qt.Equals,
+	got:     42,
+	args:    []interface{}{42},
+}, {
+	about:    "failure",
+	checker:  qt.Equals,
+	got:      42,
+	args:     []interface{}{47},
+	expected: "not equal:\n(-got +want)\n\t-: 42\n\t+: 47\n",
+}, {
+	about:    "failure with comment",
+	checker:  qt.Not(qt.IsNil),
+	got:      nil,
+	args:     []interface{}{qt.Commentf("bad %s", "wolf")},
+	expected: "bad wolf\nthe value is nil, but should not\n",
+}, {
+	about:    "not enough arguments",
+	checker:  qt.Equals,
+	got:      42,
+	expected: "not enough arguments provided to checker: got 0, want 1\n",
+}, {
+	about:    "nil checker",
+	got:      42,
+	expected: "cannot run test: nil checker provided\n",
+}}
+
+func TestRenderFailure(t *testing.T) {
+	for _, test := range renderFailureTests {
+		t.Run(test.about, func(t *testing.T) {
+			got := qt.RenderFailure(test.checker, test.got, test.args...)
+			if got != test.expected {
+				t.Fatalf("unexpected report:\ngot  %q\nwant %q", got, test.expected)
+			}
+		})
+	}
+}
+
+// linesChecker is a checker always failing with a message including the
+// number of lines provided as got.
+type linesChecker struct{}
+
+func (linesChecker) Check(got interface{}, args []interface{}) error {
+	lines := make([]string, got.(int))
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+func (linesChecker) Negate(got interface{}, args []interface{}) error {
+	return nil
+}
+
+func (linesChecker) NumArgs() int {
+	return 0
+}
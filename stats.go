@@ -0,0 +1,102 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Stats holds the number of checks and assertions run in a test.
+type Stats struct {
+	// Checks holds the number of checks run with Check or Checkf.
+	Checks int
+	// Asserts holds the number of assertions run with Assert or Assertf.
+	Asserts int
+	// Failed holds the number of failed checks and assertions.
+	Failed int
+}
+
+// Passed returns the number of checks and assertions that succeeded.
+func (s Stats) Passed() int {
+	return s.Checks + s.Asserts - s.Failed
+}
+
+// String returns a human readable summary of the stats.
+func (s Stats) String() string {
+	return fmt.Sprintf("%d checks, %d assertions: %d passed, %d failed", s.Checks, s.Asserts, s.Passed(), s.Failed)
+}
+
+// checkKind and assertKind identify the kind of a check for stats purposes.
+const (
+	checkKind = iota
+	assertKind
+)
+
+// statsCounter safely records stats, as checkers can be used concurrently.
+type statsCounter struct {
+	mu    sync.Mutex
+	stats Stats
+
+	// log holds whether the stats are logged when the test completes.
+	log bool
+	// registered holds whether the logging cleanup function has been
+	// registered.
+	registered bool
+}
+
+// add records a check of the given kind.
+func (s *statsCounter) add(kind int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if kind == assertKind {
+		s.stats.Asserts++
+		return
+	}
+	s.stats.Checks++
+}
+
+// addFailure records a failed check.
+func (s *statsCounter) addFailure() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Failed++
+}
+
+// get returns the current stats.
+func (s *statsCounter) get() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// setLog sets whether the stats are logged using t when the test completes.
+// The logging cleanup function is registered at most once, and it only logs
+// if logging is still enabled when the test completes.
+func (s *statsCounter) setLog(t testing.TB, log bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log = log
+	if !log || s.registered {
+		return
+	}
+	cl, ok := t.(cleaner)
+	if !ok {
+		return
+	}
+	s.registered = true
+	cl.Cleanup(func() {
+		s.mu.Lock()
+		log, stats := s.log, s.stats
+		s.mu.Unlock()
+		if log {
+			t.Log("quicktest: " + stats.String())
+		}
+	})
+}
+
+// cleaner is implemented by testing.TB values supporting cleanup functions.
+type cleaner interface {
+	Cleanup(func())
+}
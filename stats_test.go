@@ -0,0 +1,125 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestStats(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.Check(42, qt.Equals, 42)
+	c.Check(42, qt.Equals, 47)
+	c.Checkf(42, qt.Equals, 47, "answer")
+	c.Assert(nil, qt.IsNil)
+	c.Assertf(42, qt.IsNil, "answer")
+	c.Check(42, nil)
+	assertStats(t, c.Stats(), qt.Stats{Checks: 4, Asserts: 2, Failed: 4})
+	if got, want := c.Stats().String(), "4 checks, 2 assertions: 2 passed, 4 failed"; got != want {
+		t.Fatalf("stats string: got %q, want %q", got, want)
+	}
+}
+
+func TestStatsShared(t *testing.T) {
+	tt := &testingT{}
+	c := qt.New(tt)
+	c.CheckAll(func(c *qt.C) {
+		c.Check(42, qt.Equals, 47)
+		c.Assert(42, qt.Equals, 42)
+	})
+	c.WithComment("answer").Check(42, qt.Equals, 47)
+	assertStats(t, c.Stats(), qt.Stats{Checks: 2, Asserts: 1, Failed: 2})
+}
+
+func TestStatsSubtest(t *testing.T) {
+	c := qt.New(t)
+	c.Check(42, qt.Equals, 42)
+	c.Run("subtest", func(c *qt.C) {
+		c.Assert(42, qt.Equals, 42)
+		assertStats(t, c.Stats(), qt.Stats{Asserts: 1})
+	})
+	assertStats(t, c.Stats(), qt.Stats{Checks: 1})
+}
+
+func TestSetLogStats(t *testing.T) {
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.SetLogStats(true)
+	c.SetLogStats(true)
+	c.Check(42, qt.Equals, 42)
+	c.Assert(42, qt.Equals, 47)
+	if len(tt.logs) != 0 {
+		t.Fatalf("stats logged before the test completed: %q", tt.logs)
+	}
+	tt.cleanup()
+	if len(tt.logs) != 1 || tt.logs[0] != "quicktest: 1 checks, 1 assertions: 1 passed, 1 failed" {
+		t.Fatalf("unexpected logs: %q", tt.logs)
+	}
+}
+
+func TestSetLogStatsReenabled(t *testing.T) {
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.SetLogStats(true)
+	c.SetLogStats(false)
+	c.SetLogStats(true)
+	c.Check(42, qt.Equals, 42)
+	tt.cleanup()
+	if len(tt.logs) != 1 || tt.logs[0] != "quicktest: 1 checks, 0 assertions: 1 passed, 0 failed" {
+		t.Fatalf("unexpected logs: %q", tt.logs)
+	}
+}
+
+func TestSetLogStatsTurnedOff(t *testing.T) {
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.SetLogStats(true)
+	c.Check(42, qt.Equals, 42)
+	c.SetLogStats(false)
+	tt.cleanup()
+	if len(tt.logs) != 0 {
+		t.Fatalf("unexpected logs: %q", tt.logs)
+	}
+}
+
+func TestSetLogStatsDisabled(t *testing.T) {
+	tt := &cleanupT{}
+	c := qt.New(tt)
+	c.Check(42, qt.Equals, 42)
+	tt.cleanup()
+	if len(tt.logs) != 0 {
+		t.Fatalf("unexpected logs: %q", tt.logs)
+	}
+}
+
+func assertStats(t *testing.T, got, want qt.Stats) {
+	if got != want {
+		t.Fatalf("stats:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+// cleanupT is a testingT also supporting cleanup functions and logging.
+type cleanupT struct {
+	testingT
+	funcs []func()
+	logs  []string
+}
+
+func (t *cleanupT) Cleanup(f func()) {
+	t.funcs = append(t.funcs, f)
+}
+
+func (t *cleanupT) Log(a ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprint(a...))
+}
+
+// cleanup runs the registered cleanup functions.
+func (t *cleanupT) cleanup() {
+	for i := len(t.funcs) - 1; i >= 0; i-- {
+		t.funcs[i]()
+	}
+}
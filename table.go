@@ -0,0 +1,123 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TableCase holds a case of a table driven test run by C.CheckTable.
+type TableCase struct {
+	// Name holds the name of the subtest running the case.
+	Name string
+
+	// Func holds the function under test.
+	Func interface{}
+
+	// Inputs holds the arguments Func is called with.
+	Inputs []interface{}
+
+	// Checker holds the checker used to check the values returned by Func.
+	// If Func returns a single value, the value is checked as is. Otherwise,
+	// all the returned values are checked at once, as a []interface{} like
+	// the one returned by Results.
+	Checker Checker
+
+	// Args holds the additional arguments passed to Checker, including an
+	// optional Comment.
+	Args []interface{}
+}
+
+// CheckTable runs each of the given cases in its own subtest, started with
+// c.Run and named after the case. For each case, the function is called with
+// the case inputs, and the returned values are checked using the case checker
+// and arguments. Failures are reported at the CheckTable call, and include
+// the inputs of the failed case. For instance:
+//
+//     c.CheckTable([]qt.TableCase{{
+//         Name:    "itoa",
+//         Func:    strconv.Itoa,
+//         Inputs:  []interface{}{42},
+//         Checker: qt.Equals,
+//         Args:    []interface{}{"42"},
+//     }, {
+//         Name:    "atoi",
+//         Func:    strconv.Atoi,
+//         Inputs:  []interface{}{"42"},
+//         Checker: qt.DeepEquals,
+//         Args:    []interface{}{[]interface{}{42, nil}},
+//     }})
+//
+// CheckTable reports whether all the cases succeeded. As with c.Run, a panic
+// is raised when the embedded concrete type does not implement Run.
+func (c *C) CheckTable(cases []TableCase) bool {
+	if h, ok := c.TB.(helper); ok {
+		h.Helper()
+	}
+	file, line, found := invocation()
+	ok := true
+	for _, tc := range cases {
+		tc := tc
+		ok = c.Run(tc.Name, func(c *C) {
+			c.stats.add(checkKind)
+			c = c.WithComment("inputs: %s", formatInputs(tc.Inputs))
+			got, err := tc.call()
+			var cmt Comment
+			if err == nil {
+				cmt, err = runCheck(c.withCmpOptions(tc.Checker), got, tc.Args)
+			}
+			if err != nil {
+				c.fail(c.TB.Error, c.reportAt(err, cmt, file, line, found))
+			}
+		}) && ok
+	}
+	return ok
+}
+
+// call calls the case function with the case inputs, and returns the
+// resulting value, or values as a []interface{} if more than one value is
+// returned.
+func (tc *TableCase) call() (interface{}, error) {
+	f := reflect.ValueOf(tc.Func)
+	if f.Kind() != reflect.Func {
+		return nil, BadCheckf("expected a function, got %T instead", tc.Func)
+	}
+	ftype := f.Type()
+	if ftype.NumOut() == 0 {
+		return nil, BadCheckf("function %s does not return any values", ftype)
+	}
+	if ftype.IsVariadic() || len(tc.Inputs) != ftype.NumIn() {
+		return nil, BadCheckf("cannot call function %s with %d inputs", ftype, len(tc.Inputs))
+	}
+	var in []reflect.Value
+	var err error
+	switch ftype.NumIn() {
+	case 0:
+	case 1:
+		in, err = callArgs(ftype, tc.Inputs[0])
+	default:
+		in, err = callArgs(ftype, tc.Inputs)
+	}
+	if err != nil {
+		return nil, BadCheckf("cannot call function %s: %s", ftype, err)
+	}
+	results := callResults(f, in)
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// formatInputs returns the given inputs formatted as a comma separated list.
+func formatInputs(inputs []interface{}) string {
+	if len(inputs) == 0 {
+		return "none"
+	}
+	formatted := make([]string, len(inputs))
+	for i, input := range inputs {
+		formatted[i] = fmt.Sprintf("%#v", input)
+	}
+	return strings.Join(formatted, ", ")
+}
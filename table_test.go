@@ -0,0 +1,121 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCheckTableSuccess(t *testing.T) {
+	c := qt.New(t)
+	ok := c.CheckTable([]qt.TableCase{{
+		Name:    "single result",
+		Func:    strconv.Itoa,
+		Inputs:  []interface{}{42},
+		Checker: qt.Equals,
+		Args:    []interface{}{"42"},
+	}, {
+		Name:    "multiple results",
+		Func:    strconv.Atoi,
+		Inputs:  []interface{}{"42"},
+		Checker: qt.DeepEquals,
+		Args:    []interface{}{[]interface{}{42, nil}},
+	}, {
+		Name:    "multiple inputs",
+		Func:    strings.Repeat,
+		Inputs:  []interface{}{"a", 3},
+		Checker: qt.Equals,
+		Args:    []interface{}{"aaa"},
+	}, {
+		Name: "no inputs",
+		Func: func() int {
+			return 42
+		},
+		Checker: qt.Equals,
+		Args:    []interface{}{42},
+	}})
+	assertBool(t, ok, true)
+	if stats := c.Stats(); stats.Checks != 0 {
+		t.Fatalf("unexpected checks in the parent test: %d", stats.Checks)
+	}
+}
+
+var checkTableFailureTests = []struct {
+	about          string
+	tc             qt.TableCase
+	expectedReport string
+}{{
+	about: "check failure",
+	tc: qt.TableCase{
+		Name:    "itoa",
+		Func:    strconv.Itoa,
+		Inputs:  []interface{}{42},
+		Checker: qt.Equals,
+		Args:    []interface{}{"47"},
+	},
+	expectedReport: "\ninputs: 42\nnot equal:\n(-got +want)\n\t-: \"42\"\n\t+: \"47\"\ntable_test.go:",
+}, {
+	about: "check failure with comment",
+	tc: qt.TableCase{
+		Name:    "atoi",
+		Func:    strconv.Atoi,
+		Inputs:  []interface{}{"bad wolf"},
+		Checker: qt.HasLen,
+		Args:    []interface{}{1, qt.Commentf("two results")},
+	},
+	expectedReport: "\ninputs: \"bad wolf\"\ntwo results\nthe provided value has not the expected length of 1:\n",
+}, {
+	about: "not a function",
+	tc: qt.TableCase{
+		Func:    42,
+		Checker: qt.IsNil,
+	},
+	expectedReport: "\ninputs: none\nexpected a function, got int instead\n",
+}, {
+	about: "wrong number of inputs",
+	tc: qt.TableCase{
+		Func:    strings.Repeat,
+		Inputs:  []interface{}{"a"},
+		Checker: qt.IsNil,
+	},
+	expectedReport: "\ninputs: \"a\"\ncannot call function func(string, int) string with 1 inputs\n",
+}, {
+	about: "wrong type of inputs",
+	tc: qt.TableCase{
+		Func:    strconv.Itoa,
+		Inputs:  []interface{}{"42"},
+		Checker: qt.IsNil,
+	},
+	expectedReport: "\ninputs: \"42\"\ncannot call function func(int) string: cannot use string as argument of type int\n",
+}, {
+	about: "no results",
+	tc: qt.TableCase{
+		Func:    func() {},
+		Checker: qt.IsNil,
+	},
+	expectedReport: "\ninputs: none\nfunction func() does not return any values\n",
+}}
+
+func TestCheckTableFailure(t *testing.T) {
+	for _, test := range checkTableFailureTests {
+		t.Run(test.about, func(t *testing.T) {
+			var reports []string
+			tt := &testingT{}
+			c := qt.New(tt, qt.WithOnFailure(func(report string) {
+				reports = append(reports, report)
+			}))
+			c.CheckTable([]qt.TableCase{test.tc})
+			if tt.subTestName != test.tc.Name {
+				t.Fatalf("subtest name: got %q, want %q", tt.subTestName, test.tc.Name)
+			}
+			if len(reports) != 1 {
+				t.Fatalf("unexpected reports: %q", reports)
+			}
+			assertPrefix(t, reports[0], test.expectedReport)
+		})
+	}
+}
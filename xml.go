@@ -0,0 +1,144 @@
+// Licensed under the MIT license, see LICENCE file for details.
+
+package quicktest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// XMLEquals is a Checker checking that the provided XML document, as a
+// string or a []byte, is semantically equal to the expected value. The
+// expected value can be either an XML document, again as a string or a
+// []byte, or any Go value, in which case it is marshaled to XML first.
+// Both documents are canonicalized before being compared, so that whitespace
+// between elements, attribute ordering, comments and processing instructions
+// are not relevant.
+// For instance:
+//
+//     c.Assert(body, qt.XMLEquals, `<user id="42"><name>bad wolf</name></user>`)
+//
+// On failure, a diff of the canonicalized documents is reported.
+var XMLEquals Checker = &xmlEqualsChecker{
+	numArgs: 1,
+}
+
+type xmlEqualsChecker struct {
+	numArgs
+}
+
+// Check implements Checker.Check by checking that got and args[0] have the
+// same canonical XML form.
+func (c *xmlEqualsChecker) Check(got interface{}, args []interface{}) error {
+	gotData, ok := documentBytes(got)
+	if !ok {
+		return BadCheckf("expected an XML string or []byte, got %T instead", got)
+	}
+	gotXML, err := canonicalXML(gotData)
+	if err != nil {
+		return BadCheckf("cannot parse provided XML: %s", err)
+	}
+	want := args[0]
+	wantData, ok := documentBytes(want)
+	if !ok {
+		if wantData, err = xml.Marshal(want); err != nil {
+			return BadCheckf("cannot marshal expected value to XML: %s", err)
+		}
+	}
+	wantXML, err := canonicalXML(wantData)
+	if err != nil {
+		return BadCheckf("cannot parse expected XML: %s", err)
+	}
+	if diff := unifiedDiff("got", gotXML, "want", wantXML); diff != "" {
+		return errors.New("XML documents are not equal:\n(diff)\n" + indent(strings.TrimSuffix(diff, "\n"), "\t"))
+	}
+	return nil
+}
+
+// Negate implements Checker.Negate by checking that got and args[0] do not
+// have the same canonical XML form.
+func (c *xmlEqualsChecker) Negate(got interface{}, args []interface{}) error {
+	data, _ := documentBytes(got)
+	gotXML, _ := canonicalXML(data)
+	return NegateCheckf(c.Check(got, args), "XML documents are equal:\n(value)\n%s", indent(strings.TrimSuffix(gotXML, "\n"), "\t"))
+}
+
+// canonicalXML returns the canonical form of the given XML document, with
+// one element per line, indented according to its depth. Attributes are
+// sorted, text is trimmed, and whitespace only text, comments, processing
+// instructions and directives are ignored.
+func canonicalXML(data []byte) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var buf, text bytes.Buffer
+	depth, roots := 0, 0
+	// flush writes the text collected so far, if any.
+	flush := func() error {
+		s := strings.TrimSpace(text.String())
+		text.Reset()
+		if s == "" {
+			return nil
+		}
+		if depth == 0 {
+			return fmt.Errorf("unexpected text %q outside the root element", s)
+		}
+		fmt.Fprintf(&buf, "%s%q\n", strings.Repeat("  ", depth), s)
+		return nil
+	}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if err := flush(); err != nil {
+				return "", err
+			}
+			if depth == 0 {
+				roots++
+			}
+			var attrs []string
+			for _, attr := range tok.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					// Namespace declarations are already resolved in names.
+					continue
+				}
+				attrs = append(attrs, fmt.Sprintf(" %s=%q", xmlName(attr.Name), attr.Value))
+			}
+			sort.Strings(attrs)
+			fmt.Fprintf(&buf, "%s<%s%s>\n", strings.Repeat("  ", depth), xmlName(tok.Name), strings.Join(attrs, ""))
+			depth++
+		case xml.EndElement:
+			if err := flush(); err != nil {
+				return "", err
+			}
+			depth--
+			fmt.Fprintf(&buf, "%s</%s>\n", strings.Repeat("  ", depth), xmlName(tok.Name))
+		case xml.CharData:
+			text.Write(tok)
+		}
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+	if roots != 1 {
+		return "", fmt.Errorf("expected a single root element, found %d", roots)
+	}
+	return buf.String(), nil
+}
+
+// xmlName returns the given XML name, including its namespace if present.
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}